@@ -0,0 +1,134 @@
+package request
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// CharsetDecoder decoder of text in a charset into UTF-8
+type CharsetDecoder func(data []byte) (string, error)
+
+// charsets registry of charset decoders keyed by lower case charset name
+var charsets = struct {
+	mutex    *sync.RWMutex
+	registry map[string]CharsetDecoder
+}{
+	mutex: &sync.RWMutex{},
+	registry: map[string]CharsetDecoder{
+		"utf-8":        decodeUtf8,
+		"utf8":         decodeUtf8,
+		"us-ascii":     decodeAscii,
+		"ascii":        decodeAscii,
+		"iso-8859-1":   singleByte(nil),
+		"iso8859-1":    singleByte(nil),
+		"iso_8859-1":   singleByte(nil),
+		"latin1":       singleByte(nil),
+		"l1":           singleByte(nil),
+		"windows-1252": singleByte(windows1252),
+		"cp1252":       singleByte(windows1252),
+		"iso-8859-15":  singleByte(iso885915),
+		"iso8859-15":   singleByte(iso885915),
+		"iso_8859-15":  singleByte(iso885915),
+		"latin-9":      singleByte(iso885915),
+		"latin9":       singleByte(iso885915),
+	},
+}
+
+// windows1252 code points of windows-1252 which differ from ISO-8859-1
+var windows1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž',
+	0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// iso885915 code points of ISO-8859-15 which differ from ISO-8859-1
+var iso885915 = map[byte]rune{
+	0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž', 0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ',
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Text get response body as UTF-8 text, decoded by charset parameter of response Content-Type
+// UTF-8 is assumed if there is no charset, invalid UTF-8 sequences are replaced by U+FFFD
+// return ErrCharsetUnsupported if charset is not registered, refer to RegisterCharset
+func (res Result) Text() (string, error) {
+	charset := "utf-8"
+	if _, params, err := mime.ParseMediaType(res.Header.Get("Content-Type")); err == nil && params["charset"] != "" {
+		charset = params["charset"]
+	}
+
+	decoder, ok := LookupCharset(charset)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrCharsetUnsupported, charset)
+	}
+	return decoder(res.Body)
+}
+
+//┌ Functions
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// RegisterCharset register decoder of charset, it replaces decoder registered before
+// name is case insensitive, aliases are registered separately,
+// e.g. RegisterCharset("shift_jis", func(data []byte) (string, error) { ... })
+func RegisterCharset(name string, decoder CharsetDecoder) {
+	charsets.mutex.Lock()
+	defer charsets.mutex.Unlock()
+	charsets.registry[strings.ToLower(strings.TrimSpace(name))] = decoder
+}
+
+// LookupCharset find decoder of charset
+func LookupCharset(name string) (CharsetDecoder, bool) {
+	charsets.mutex.RLock()
+	defer charsets.mutex.RUnlock()
+	decoder, ok := charsets.registry[strings.ToLower(strings.TrimSpace(name))]
+	return decoder, ok
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// decodeUtf8 decode UTF-8 text, byte order mark is removed and invalid sequences are replaced by U+FFFD
+func decodeUtf8(data []byte) (string, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	if utf8.ValidString(text) {
+		return text, nil
+	}
+	return strings.ToValidUTF8(text, string(utf8.RuneError)), nil
+}
+
+// decodeAscii decode US-ASCII text, bytes out of range are replaced by U+FFFD
+func decodeAscii(data []byte) (string, error) {
+	var builder strings.Builder
+	builder.Grow(len(data))
+	for _, b := range data {
+		if b < utf8.RuneSelf {
+			builder.WriteByte(b)
+		} else {
+			builder.WriteRune(utf8.RuneError)
+		}
+	}
+	return builder.String(), nil
+}
+
+// singleByte create decoder of single byte charset, bytes are ISO-8859-1 code points except overrides
+func singleByte(overrides map[byte]rune) CharsetDecoder {
+	return func(data []byte) (string, error) {
+		var builder strings.Builder
+		builder.Grow(len(data))
+		for _, b := range data {
+			if r, ok := overrides[b]; ok {
+				builder.WriteRune(r)
+			} else {
+				builder.WriteRune(rune(b))
+			}
+		}
+		return builder.String(), nil
+	}
+}
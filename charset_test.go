@@ -0,0 +1,67 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func TestResult_Text(t *testing.T) {
+	RegisterCharset("X-Reversed", func(data []byte) (string, error) {
+		runes := []rune(string(data))
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), nil
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		want        string
+		wantErr     error
+	}{
+		{name: "no charset", contentType: "text/plain", body: []byte("héllo"), want: "héllo"},
+		{name: "no content type", body: []byte("héllo"), want: "héllo"},
+		{name: "utf-8 with byte order mark", contentType: "text/plain; charset=UTF-8", body: []byte("\xef\xbb\xbfhi"), want: "hi"},
+		{name: "invalid utf-8", contentType: "text/plain; charset=utf-8", body: []byte("a\xffb"), want: "a�b"},
+		{name: "iso-8859-1", contentType: "text/html; charset=ISO-8859-1", body: []byte("caf\xe9 \xa4"), want: "café ¤"},
+		{name: "latin1 quoted", contentType: `text/plain; charset="latin1"`, body: []byte("\xfc"), want: "ü"},
+		{name: "windows-1252", contentType: "text/plain; charset=windows-1252", body: []byte("\x93quote\x94 \x80 \x81"), want: "“quote” € \u0081"},
+		{name: "iso-8859-15", contentType: "text/plain; charset=iso-8859-15", body: []byte("\xa4 \xbd"), want: "€ œ"},
+		{name: "us-ascii", contentType: "text/plain; charset=us-ascii", body: []byte("a\xe9"), want: "a�"},
+		{name: "registered", contentType: "text/plain; charset=x-reversed", body: []byte("abc"), want: "cba"},
+		{name: "unsupported", contentType: "text/plain; charset=koi8-r", body: []byte("abc"), wantErr: ErrCharsetUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Result{Body: tt.body, Header: http.Header{}}
+			if tt.contentType != "" {
+				res.Header.Set("Content-Type", tt.contentType)
+			}
+			got, err := res.Text()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Result.Text() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Result.Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupCharset(t *testing.T) {
+	for _, name := range []string{"UTF-8", "utf8", "Latin1", "CP1252", "ISO_8859-15", "us-ascii"} {
+		decoder, ok := LookupCharset(name)
+		if !ok {
+			t.Errorf("LookupCharset(%q) is not found", name)
+			continue
+		}
+		if got, err := decoder([]byte("plain")); err != nil || got != "plain" {
+			t.Errorf("LookupCharset(%q) decodes %q, %v", name, got, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package request
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	net_url "net/url"
+	"strings"
+	"sync"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Codec encoder and decoder of a content type
+type Codec struct {
+	// Encode encode payload of request
+	Encode func(v any) ([]byte, error)
+
+	// Decode decode body of response into v
+	Decode func(data []byte, v any) error
+}
+
+// codecs registry of codecs keyed by media type
+var codecs = struct {
+	mutex    *sync.RWMutex
+	registry map[string]Codec
+}{
+	mutex: &sync.RWMutex{},
+	registry: map[string]Codec{
+		string(HTTP_JSON): {Encode: json.Marshal, Decode: json.Unmarshal},
+		string(HTTP_FORM): FormCodec(ValuesEncoder{Tag: "form"}),
+		string(HTTP_XML):  {Encode: xml.Marshal, Decode: xml.Unmarshal},
+		"text/xml":        {Encode: xml.Marshal, Decode: xml.Unmarshal},
+	},
+}
+
+//┌ Functions
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// RegisterCodec register codec of content type, it replaces codec registered before
+// content type parameters are ignored, e.g. "text/csv; charset=utf-8" registers "text/csv"
+func RegisterCodec(ctype ContentType, codec Codec) {
+	codecs.mutex.Lock()
+	defer codecs.mutex.Unlock()
+	codecs.registry[mediaType(string(ctype))] = codec
+}
+
+// LookupCodec find codec of content type
+// types with structured syntax suffix fall back to codec of suffix, e.g. "application/problem+json" uses "application/json"
+func LookupCodec(ctype string) (Codec, bool) {
+	codecs.mutex.RLock()
+	defer codecs.mutex.RUnlock()
+
+	name := mediaType(ctype)
+	if codec, ok := codecs.registry[name]; ok {
+		return codec, true
+	}
+	if _, suffix, found := strings.Cut(name, "+"); found {
+		codec, ok := codecs.registry["application/"+suffix]
+		return codec, ok
+	}
+	return Codec{}, false
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// encoder find encode function of codec of content type
+// return ErrCodecNotFound if there is no codec or it has no Encode, e.g. a decode only codec
+func encoder(ctype string) (func(v any) ([]byte, error), error) {
+	codec, ok := LookupCodec(ctype)
+	if !ok || codec.Encode == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCodecNotFound, ctype)
+	}
+	return codec.Encode, nil
+}
+
+// encodeForm encode www form payload
+// data is `string` sent as is, or struct, map or pointer to them encoded by encoder
+func encodeForm(encoder ValuesEncoder, data any) ([]byte, error) {
+	if payload, ok := data.(string); ok {
+		return []byte(payload), nil
+	}
+	values, err := encoder.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(values.Encode()), nil
+}
+
+// decodeForm decode www form body into `*url.Values` or `*map[string]string`
+func decodeForm(data []byte, v any) error {
+	values, err := net_url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+	switch target := v.(type) {
+	case *net_url.Values:
+		*target = values
+	case *map[string]string:
+		*target = make(map[string]string, len(values))
+		for k := range values {
+			(*target)[k] = values.Get(k)
+		}
+	default:
+		return ErrDecodeUnsupported
+	}
+	return nil
+}
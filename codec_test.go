@@ -0,0 +1,133 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func TestRegisterCodec(t *testing.T) {
+	const HTTP_LIST ContentType = "text/x-list"
+	RegisterCodec(HTTP_LIST, Codec{
+		Encode: func(v any) ([]byte, error) {
+			return []byte(strings.Join(v.([]string), ",")), nil
+		},
+		Decode: func(data []byte, v any) error {
+			*(v.(*[]string)) = strings.Split(string(data), ",")
+			return nil
+		},
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		demand  Demand
+		data    any
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "registered",
+			demand: BuildDemand(http.MethodPost, server.URL, "").ContentType(HTTP_LIST + "; charset=utf-8"),
+			data:   []string{"a", "b"},
+			want:   []string{"a", "b"},
+		},
+		{
+			name:    "not registered",
+			demand:  BuildDemand(http.MethodPost, server.URL, "").ContentType("application/x-unknown"),
+			data:    []string{"a"},
+			wantErr: ErrCodecNotFound,
+		},
+		{
+			name:    "no content type",
+			demand:  BuildDemand(http.MethodPost, server.URL, ""),
+			data:    []string{"a"},
+			wantErr: ErrDemandContentTypeEmpty,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, props, ok := New(MAX_TIMEOUT, nil).SendData(tt.demand, tt.data)
+			if tt.wantErr != nil {
+				if ok || !errors.Is(LastError(props.Errors), tt.wantErr) {
+					t.Fatalf("request.SendData() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("request.SendData() errors = %v", props.Errors)
+			}
+			got, err := Decode[[]string](result)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupCodec(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctype  string
+		wantOk bool
+	}{
+		{name: "json", ctype: "application/json", wantOk: true},
+		{name: "json with parameters", ctype: "Application/JSON; charset=utf-8", wantOk: true},
+		{name: "json suffix", ctype: "application/problem+json", wantOk: true},
+		{name: "form", ctype: "application/x-www-form-urlencoded", wantOk: true},
+		{name: "unknown", ctype: "application/x-unknown", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := LookupCodec(tt.ctype); ok != tt.wantOk {
+				t.Errorf("LookupCodec() ok = %v, want %v", ok, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRegisterCodec_decodeOnly(t *testing.T) {
+	for _, ctype := range []ContentType{HTTP_JSON, HTTP_FORM, HTTP_XML} {
+		codec, _ := LookupCodec(string(ctype))
+		t.Cleanup(func() { RegisterCodec(ctype, codec) })
+		RegisterCodec(ctype, Codec{Decode: codec.Decode})
+	}
+
+	r := New(MAX_TIMEOUT, nil)
+	d := BuildDemand(http.MethodPost, "http://localhost", "")
+	tests := []struct {
+		name string
+		send func() (Result, Properties, bool)
+	}{
+		{name: "json", send: func() (Result, Properties, bool) { return r.SendJson(d, "payload") }},
+		{name: "form", send: func() (Result, Properties, bool) { return r.SendForm(d, "payload") }},
+		{name: "xml", send: func() (Result, Properties, bool) { return r.SendXml(d, "payload") }},
+		{name: "data", send: func() (Result, Properties, bool) { return r.SendData(d.ContentType(HTTP_JSON), "payload") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, props, ok := tt.send()
+			if ok || !errors.Is(LastError(props.Errors), ErrCodecNotFound) {
+				t.Errorf("send error = %v, want %v", LastError(props.Errors), ErrCodecNotFound)
+			}
+			if props.Retries != 0 {
+				t.Errorf("Properties.Retries = %v, want 0", props.Retries)
+			}
+		})
+	}
+}
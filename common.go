@@ -8,7 +8,9 @@ import (
 //──────────────────────────────────────────────────────────────────────────────────────────────────
 
 const (
-	MAX_TIMEOUT time.Duration = 30 * time.Minute
+	MAX_TIMEOUT   time.Duration = 30 * time.Minute
+	MAX_REDIRECTS int           = 10
+	MAX_LINE_SIZE int           = 1 << 20
 )
 
 //┌ Content Types
@@ -17,8 +19,10 @@ const (
 type ContentType string
 
 const (
-	HTTP_JSON ContentType = "application/json"
-	HTTP_FORM ContentType = "application/x-www-form-urlencoded"
+	HTTP_JSON      ContentType = "application/json"
+	HTTP_FORM      ContentType = "application/x-www-form-urlencoded"
+	HTTP_MULTIPART ContentType = "multipart/form-data"
+	HTTP_XML       ContentType = "application/xml"
 )
 
 //┌ Errors
@@ -28,4 +32,32 @@ var (
 	ErrDemandContentTypeEmpty error = errors.New("content type is empty")
 	ErrDemandTokenEmpty       error = errors.New("token is empty")
 	ErrDemandParamEmpty       error = errors.New("params is empty")
+	ErrDemandCookieEmpty      error = errors.New("cookie is empty")
+	ErrDemandContextNil       error = errors.New("context is nil")
+	ErrDemandChecksumInvalid  error = errors.New("checksum is not valid hex")
+	ErrRedirectMaxHops        error = errors.New("too many redirects")
+	ErrRedirectCrossHost      error = errors.New("redirect to another host")
+	ErrBodyNotReplayable      error = errors.New("body can not be sent again")
+	ErrRawBodyUnsupported     error = errors.New("raw body type is not supported")
+	ErrMultipartFileEmpty     error = errors.New("multipart file has no source")
+	ErrResponseTooLarge       error = errors.New("response body is too large")
+	ErrResponseStatus         error = errors.New("unexpected response status")
+	ErrEventStream            error = errors.New("response is not an event stream")
+	ErrLineTooLong            error = errors.New("line is too long")
+	ErrDownloadStatus         error = errors.New("unexpected download status")
+	ErrDownloadRange          error = errors.New("invalid download range")
+	ErrDownloadIncomplete     error = errors.New("download is incomplete")
+	ErrDownloadIdle           error = errors.New("download is idle")
+	ErrDigestUnsupported      error = errors.New("digest algorithm is not supported")
+	ErrDigestMismatch         error = errors.New("digest mismatch")
+	ErrEncodingUnsupported    error = errors.New("content encoding is not supported")
+	ErrEncodeBody             error = errors.New("can not encode body")
+	ErrDecodeBody             error = errors.New("can not decode body")
+	ErrDecodeUnsupported      error = errors.New("content type is not supported to decode")
+	ErrCharsetUnsupported     error = errors.New("charset is not supported")
+	ErrCodecNotFound          error = errors.New("no codec registered for content type")
+	ErrValuesUnsupported      error = errors.New("type is not supported to encode values")
+	ErrSoapFault              error = errors.New("soap fault")
+	ErrSoapEnvelope           error = errors.New("body is not a soap envelope")
+	ErrSoapVersion            error = errors.New("soap version is not supported")
 )
@@ -0,0 +1,156 @@
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Encoding content coding of body
+type Encoding string
+
+const (
+	ENCODING_GZIP    Encoding = "gzip"
+	ENCODING_DEFLATE Encoding = "deflate"
+)
+
+// Compression compression of request and response bodies
+type Compression struct {
+	// Encoding content coding of request body, empty means no compression
+	// only encoded and in-memory bodies are compressed, streamed bodies (SendUpload, SendMultipart, readers of SendRaw) are not
+	Encoding Encoding
+
+	// Threshold minimum size of request body in bytes to be compressed
+	Threshold int
+
+	// Accept content codings of response negotiated via Accept-Encoding and decompressed transparently,
+	// empty leaves negotiation to http.Transport
+	Accept []Encoding
+}
+
+// decodedBody decompressed body which closes the original body
+// decompressor is created on first read, so an empty body is not decoded
+type decodedBody struct {
+	body   io.ReadCloser
+	open   func(r *bufio.Reader) (io.ReadCloser, error)
+	reader io.ReadCloser
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Compress set compression of request and response bodies
+func (r request) Compress(compression Compression) Request {
+	r.Compression = compression
+	return r
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// compress compress request body if it reaches threshold
+// return encoding used, empty if body is not compressed
+func (c Compression) compress(body []byte) ([]byte, Encoding, error) {
+	if c.Encoding == "" || len(body) < c.Threshold {
+		return body, "", nil
+	}
+
+	var (
+		buffer bytes.Buffer
+		writer io.WriteCloser
+	)
+	switch c.Encoding {
+	case ENCODING_GZIP:
+		writer = gzip.NewWriter(&buffer)
+	case ENCODING_DEFLATE:
+		writer = zlib.NewWriter(&buffer)
+	default:
+		return nil, "", ErrEncodingUnsupported
+	}
+
+	if _, err := writer.Write(body); err != nil {
+		return nil, "", err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buffer.Bytes(), c.Encoding, nil
+}
+
+// accept get Accept-Encoding header value, empty if negotiation is left to http.Transport
+func (c Compression) accept() string {
+	encodings := make([]string, 0, len(c.Accept))
+	for _, encoding := range c.Accept {
+		encodings = append(encodings, string(encoding))
+	}
+	return strings.Join(encodings, ", ")
+}
+
+// decompress wrap body to decompress content coding
+// unknown encodings are left as is
+func decompress(encoding string, body io.ReadCloser) io.ReadCloser {
+	switch Encoding(strings.ToLower(strings.TrimSpace(encoding))) {
+	case ENCODING_GZIP:
+		return &decodedBody{body: body, open: func(r *bufio.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		}}
+	case ENCODING_DEFLATE:
+		return &decodedBody{body: body, open: inflate}
+	default:
+		return body
+	}
+}
+
+// inflate create decompressor of "deflate" coding
+// it should be zlib format, though some servers send raw deflate
+func inflate(r *bufio.Reader) (io.ReadCloser, error) {
+	if header, err := r.Peek(2); err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(r)
+	}
+	return flate.NewReader(r), nil
+}
+
+// bodyless report whether response has no body to decompress, e.g. response of HEAD, 204 and 304
+func bodyless(response *http.Response) bool {
+	return response.ContentLength == 0 ||
+		(response.Request != nil && response.Request.Method == http.MethodHead) ||
+		(response.StatusCode >= 100 && response.StatusCode < 200) ||
+		response.StatusCode == http.StatusNoContent ||
+		response.StatusCode == http.StatusNotModified
+}
+
+// Read implements io.Reader
+func (d *decodedBody) Read(p []byte) (int, error) {
+	if d.reader == nil {
+		buffered := bufio.NewReader(d.body)
+		if _, err := buffered.Peek(1); err != nil {
+			// empty body is not encoded
+			return 0, err
+		}
+		reader, err := d.open(buffered)
+		if err != nil {
+			return 0, err
+		}
+		d.reader = reader
+	}
+	return d.reader.Read(p)
+}
+
+// Close implements io.Closer
+func (d *decodedBody) Close() error {
+	var err error
+	if d.reader != nil {
+		err = d.reader.Close()
+	}
+	if closeErr := d.body.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
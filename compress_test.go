@@ -0,0 +1,197 @@
+package request
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_Compress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		switch r.Header.Get("Content-Encoding") {
+		case "gzip":
+			body, _ = gzip.NewReader(r.Body)
+		case "deflate":
+			body, _ = zlib.NewReader(r.Body)
+		}
+		received, _ := io.ReadAll(body)
+		content := []byte(r.Header.Get("Content-Encoding") + ":" + string(received))
+
+		accept := r.Header.Get("Accept-Encoding")
+		switch {
+		case strings.Contains(accept, "gzip"):
+			w.Header().Set("Content-Encoding", "gzip")
+			writer := gzip.NewWriter(w)
+			writer.Write(content)
+			writer.Close()
+		case strings.Contains(accept, "deflate"):
+			w.Header().Set("Content-Encoding", "deflate")
+			writer := zlib.NewWriter(w)
+			writer.Write(content)
+			writer.Close()
+		default:
+			w.Write(content)
+		}
+	}))
+	defer server.Close()
+
+	payload := strings.Repeat("a", 100)
+
+	tests := []struct {
+		name         string
+		compression  Compression
+		want         string
+		wantEncoding string
+	}{
+		{
+			name:         "transport default",
+			compression:  Compression{},
+			want:         ":" + payload,
+			wantEncoding: "gzip",
+		},
+		{
+			name:         "gzip request, deflate response",
+			compression:  Compression{Encoding: ENCODING_GZIP, Accept: []Encoding{ENCODING_DEFLATE}},
+			want:         "gzip:" + payload,
+			wantEncoding: "deflate",
+		},
+		{
+			name:         "deflate request under threshold",
+			compression:  Compression{Encoding: ENCODING_DEFLATE, Threshold: 1000, Accept: []Encoding{ENCODING_GZIP}},
+			want:         ":" + payload,
+			wantEncoding: "gzip",
+		},
+		{
+			name:         "deflate request",
+			compression:  Compression{Encoding: ENCODING_DEFLATE, Threshold: 10, Accept: []Encoding{ENCODING_GZIP, ENCODING_DEFLATE}},
+			want:         "deflate:" + payload,
+			wantEncoding: "gzip",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(MAX_TIMEOUT, nil).Compress(tt.compression)
+			got, props, ok := r.SendForm(BuildDemand(http.MethodPost, server.URL, ""), payload)
+			if !ok {
+				t.Fatalf("request.SendForm() errors = %v", props.Errors)
+			}
+			if string(got.Body) != tt.want {
+				t.Errorf("Result.Body = %q, want %q", got.Body, tt.want)
+			}
+			if got.ContentEncoding != tt.wantEncoding {
+				t.Errorf("Result.ContentEncoding = %q, want %q", got.ContentEncoding, tt.wantEncoding)
+			}
+
+			streamed, _, ok := r.Stream(BuildDemand(http.MethodPost, server.URL, ""), bytes.NewReader([]byte(payload)))
+			if !ok {
+				t.Fatalf("request.Stream() failed")
+			}
+			defer streamed.Stream.Close()
+			if body, _ := io.ReadAll(streamed.Stream); !strings.HasSuffix(string(body), payload) {
+				t.Errorf("Result.Stream = %q, want decompressed content", body)
+			}
+		})
+	}
+}
+
+func Test_request_Compress_emptyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		switch r.URL.Path {
+		case "/no-content":
+			w.WriteHeader(http.StatusNoContent)
+		case "/not-modified":
+			w.WriteHeader(http.StatusNotModified)
+		case "/chunked":
+			w.(http.Flusher).Flush()
+		default:
+			w.Header().Set("Content-Length", "100")
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "head", method: http.MethodHead, path: "/", wantStatus: http.StatusOK},
+		{name: "no content", method: http.MethodGet, path: "/no-content", wantStatus: http.StatusNoContent},
+		{name: "not modified", method: http.MethodGet, path: "/not-modified", wantStatus: http.StatusNotModified},
+		{name: "empty chunked", method: http.MethodGet, path: "/chunked", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(MAX_TIMEOUT, []time.Duration{0, 0}).Compress(Compression{Accept: []Encoding{ENCODING_GZIP}})
+			got, props, ok := r.Send(BuildDemand(tt.method, server.URL, tt.path))
+			if !ok {
+				t.Fatalf("request.Send() errors = %v", props.Errors)
+			}
+			if got.StatusCode != tt.wantStatus || len(got.Body) != 0 || props.Retries != 1 {
+				t.Errorf("Result status = %v, body = %q, retries = %v, want %v, empty, 1", got.StatusCode, got.Body, props.Retries, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_request_Compress_download(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+
+	var encoded bytes.Buffer
+	writer := gzip.NewWriter(&encoded)
+	writer.Write(content)
+	writer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("Range") != "" {
+			if r.Header.Get("Accept-Encoding") != "identity" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+			return
+		}
+		// drop connection in the middle of encoded content
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Length", strconv.Itoa(encoded.Len()))
+		w.Write(encoded.Bytes()[:encoded.Len()/2])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		segments int
+	}{
+		{name: "resumed", segments: 0},
+		{name: "segmented", segments: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "artifact.bin")
+			r := New(MAX_TIMEOUT, []time.Duration{0, 0}).Compress(Compression{Accept: []Encoding{ENCODING_GZIP}})
+			_, props, ok := r.Download(BuildDemand(http.MethodGet, server.URL, ""), Target{Path: path, Segments: tt.segments})
+			if !ok {
+				t.Fatalf("request.Download() errors = %v", props.Errors)
+			}
+			if got, err := os.ReadFile(path); err != nil || !bytes.Equal(got, content) {
+				t.Errorf("downloaded file differs, error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package request
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Typed result with response body decoded into success type T or error type E
+type Typed[T any, E any] struct {
+	Result
+
+	// Value decoded body of successful (2xx) response
+	Value T
+
+	// Failure decoded body of unsuccessful response
+	Failure E
+
+	// Err decoding error as *DecodeError, nil on success
+	Err error
+}
+
+// DecodeError failure of decoding response body, distinct from transport failures
+type DecodeError struct {
+	// ContentType media type of response body
+	ContentType string
+
+	// Err cause of failure
+	Err error
+}
+
+//┌ Functions
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Fetch send http request and decode response body into T on success (2xx) or into E otherwise
+// return False on transport failures, refer to Properties.Errors
+// decoding failures are reported in Typed.Err
+func Fetch[T any, E any](r Request, c Demand) (Typed[T, E], Properties, bool) {
+	result, properties, success := r.Send(c)
+	if !success {
+		return Typed[T, E]{Result: result}, properties, false
+	}
+	return DecodeResult[T, E](result), properties, true
+}
+
+// DecodeResult decode response body into T on success (2xx) or into E otherwise
+func DecodeResult[T any, E any](res Result) Typed[T, E] {
+	typed := Typed[T, E]{Result: res}
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		typed.Value, typed.Err = Decode[T](res)
+	} else {
+		typed.Failure, typed.Err = Decode[E](res)
+	}
+	return typed
+}
+
+// Decode decode response body into T, decoder is chosen by response Content-Type, refer to RegisterCodec
+// JSON body is decoded by JsonOptions of request
+// string and []byte receive the body as is, empty body results zero value
+func Decode[T any](res Result) (T, error) {
+	var value T
+	if err := decode(res, &value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Error implements error
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s (%s): %s", ErrDecodeBody, e.ContentType, e.Err)
+}
+
+// Unwrap get cause of failure
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Is report whether target is ErrDecodeBody
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecodeBody
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// decode decode response body into v
+func decode(res Result, v any) error {
+	switch target := v.(type) {
+	case *string:
+		*target = string(res.Body)
+		return nil
+	case *[]byte:
+		*target = res.Body
+		return nil
+	}
+
+	if len(res.Body) == 0 {
+		return nil
+	}
+
+	mediaType := mediaType(res.Header.Get("Content-Type"))
+
+	if mediaType == "" {
+		// assume JSON when response has no content type
+		mediaType = string(HTTP_JSON)
+	}
+
+	var err error
+	if isJson(mediaType) && res.json.decoding() {
+		err = res.json.unmarshal(res.Body, v)
+	} else if codec, ok := LookupCodec(mediaType); ok && codec.Decode != nil {
+		err = codec.Decode(res.Body, v)
+	} else {
+		err = ErrDecodeUnsupported
+	}
+	if err != nil {
+		return &DecodeError{ContentType: mediaType, Err: err}
+	}
+	return nil
+}
+
+// mediaType get media type of Content-Type header value in lower case, without parameters
+func mediaType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		parsed, _, _ = strings.Cut(contentType, ";")
+	}
+	return strings.ToLower(strings.TrimSpace(parsed))
+}
+
+// isJson report whether media type is JSON, including "+json" suffix types
+func isJson(mediaType string) bool {
+	return mediaType == string(HTTP_JSON) || strings.HasSuffix(mediaType, "+json")
+}
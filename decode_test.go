@@ -0,0 +1,90 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func TestFetch(t *testing.T) {
+	type item struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	type problem struct {
+		Title string `json:"title"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/item":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Write([]byte(`{"id":9007199254740993,"name":"book"}`))
+		case "/missing":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"title":"not found"}`))
+		case "/broken":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":`))
+		case "/text":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte(`id,name`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		path        string
+		wantValue   item
+		wantFailure problem
+		wantErr     error
+	}{
+		{
+			name:      "success",
+			path:      "/item",
+			wantValue: item{ID: 9007199254740993, Name: "book"},
+		},
+		{
+			name:        "failure",
+			path:        "/missing",
+			wantFailure: problem{Title: "not found"},
+		},
+		{
+			name:    "broken",
+			path:    "/broken",
+			wantErr: ErrDecodeBody,
+		},
+		{
+			name:    "unsupported",
+			path:    "/text",
+			wantErr: ErrDecodeUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, props, ok := Fetch[item, problem](New(MAX_TIMEOUT, nil), BuildDemand(http.MethodGet, server.URL, tt.path))
+			if !ok {
+				t.Fatalf("Fetch() errors = %v", props.Errors)
+			}
+			if !errors.Is(got.Err, tt.wantErr) {
+				t.Fatalf("Typed.Err = %v, want %v", got.Err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got.Value, tt.wantValue) {
+				t.Errorf("Typed.Value = %v, want %v", got.Value, tt.wantValue)
+			}
+			if !reflect.DeepEqual(got.Failure, tt.wantFailure) {
+				t.Errorf("Typed.Failure = %v, want %v", got.Failure, tt.wantFailure)
+			}
+		})
+	}
+
+	if _, props, ok := Fetch[item, problem](New(MAX_TIMEOUT, nil), BuildDemand(http.MethodGet, "http://127.0.0.1:1", "")); ok || len(props.Errors) == 0 {
+		t.Errorf("Fetch() transport failure not reported")
+	}
+}
@@ -1,20 +1,32 @@
 package request
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
 	net_url "net/url"
+	"slices"
 )
 
 //──────────────────────────────────────────────────────────────────────────────────────────────────
 
 type Demand struct {
-	URI     net_url.URL
-	Token   string
-	Type    string
-	Method  string
-	Headers map[string]string
-	Error   error
+	URI       net_url.URL
+	Token     string
+	Type      string
+	Method    string
+	Headers   map[string]string
+	Cookies   []*http.Cookie
+	Limit     ResponseLimit
+	Integrity Integrity
+	Json      *JsonOptions
+	Error     error
+
+	ctx    context.Context
+	tracer *tracer
+	stream bool
 }
 
 //┌ Instance
@@ -84,9 +96,37 @@ func (c Demand) Authorization(token string) Demand {
 	return c
 }
 
-// AddHeader add additional header
+// Header add additional header
 func (c Demand) Header(name, value string) Demand {
-	c.Headers[name] = value
+	headers := make(map[string]string, len(c.Headers)+1)
+	maps.Copy(headers, c.Headers)
+	headers[name] = value
+	c.Headers = headers
+	return c
+}
+
+// Cookie add cookie to send with request
+func (c Demand) Cookie(name, value string) Demand {
+	return c.AddCookie(&http.Cookie{Name: name, Value: value})
+}
+
+// AddCookie add cookie to send with request
+func (c Demand) AddCookie(cookie *http.Cookie) Demand {
+	if cookie == nil || cookie.Name == "" {
+		c.Error = errors.Join(c.Error, ErrDemandCookieEmpty)
+		return c
+	}
+	c.Cookies = append(slices.Clip(c.Cookies), cookie)
+	return c
+}
+
+// Context set context of request, canceling it aborts the attempt and waiting between retries
+func (c Demand) Context(ctx context.Context) Demand {
+	if ctx == nil {
+		c.Error = errors.Join(c.Error, ErrDemandContextNil)
+		return c
+	}
+	c.ctx = ctx
 	return c
 }
 
@@ -94,33 +134,44 @@ func (c Demand) Header(name, value string) Demand {
 // params is payload of data in types:
 // `map[string]string`,
 // `map[string]any`,
-// `url.Values`
+// `url.Values`,
+// struct or pointer to struct with `query` tags, refer to ValuesEncoder
+// nested structs and maps are encoded in bracket notation, e.g. `filter[lang]=en`,
+// slices repeat the key, e.g. `tags=a&tags=b`
 func (c Demand) Parameter(params any) Demand {
+	return c.ParameterWith(ValuesEncoder{Tag: "query", Nesting: NESTING_BRACKET}, params)
+}
+
+// ParameterWith add query parameters to the URL encoded by encoder, refer to Parameter
+// e.g. ValuesEncoder{Tag: "query", Nesting: NESTING_BRACKET, Arrays: ARRAY_COMMA}
+func (c Demand) ParameterWith(encoder ValuesEncoder, params any) Demand {
 	if params == nil {
 		c.Error = errors.Join(c.Error, ErrDemandParamEmpty)
 		return c
 	}
 
-	query := c.URI.Query()
-
-	switch payload := params.(type) {
-	case map[string]string:
-		for k, v := range payload {
-			query.Add(k, v)
-		}
-	case map[string]any:
-		for k, v := range payload {
-			query.Add(k, fmt.Sprintf("%v", v))
-		}
-	case net_url.Values:
-		for k, v := range payload {
-			for _, vv := range v {
-				query.Add(k, vv)
-			}
-		}
+	values, err := encoder.Encode(params)
+	if err != nil {
+		c.Error = errors.Join(c.Error, err)
+		return c
 	}
 
+	query := c.URI.Query()
+	for k, v := range values {
+		query[k] = append(query[k], v...)
+	}
 	c.URI.RawQuery = query.Encode()
 
 	return c
 }
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// requestContext get context of request, background if it is not set
+func (c Demand) requestContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
@@ -1,9 +1,14 @@
 package request
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	net_url "net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 //──────────────────────────────────────────────────────────────────────────────────────────────────
@@ -230,7 +235,34 @@ func TestDemand_Parameter(t *testing.T) {
 		args   args
 		want   Demand
 	}{
-		// TODO: Add test cases.
+		{
+			name:   "map",
+			fields: fields{URI: net_url.URL{Scheme: "http", Host: "localhost", RawQuery: "a=1"}},
+			args:   args{params: map[string]string{"b": "2"}},
+			want:   Demand{URI: net_url.URL{Scheme: "http", Host: "localhost", RawQuery: "a=1&b=2"}},
+		},
+		{
+			name:   "nested map",
+			fields: fields{URI: net_url.URL{Scheme: "http", Host: "localhost"}},
+			args:   args{params: map[string]any{"filter": map[string]any{"lang": "en", "tags": []string{"a", "b"}}}},
+			want:   Demand{URI: net_url.URL{Scheme: "http", Host: "localhost", RawQuery: "filter%5Blang%5D=en&filter%5Btags%5D=a&filter%5Btags%5D=b"}},
+		},
+		{
+			name:   "values",
+			fields: fields{URI: net_url.URL{Scheme: "http", Host: "localhost", RawQuery: "a=1"}},
+			args:   args{params: net_url.Values{"a": {"2", "3"}}},
+			want:   Demand{URI: net_url.URL{Scheme: "http", Host: "localhost", RawQuery: "a=1&a=2&a=3"}},
+		},
+		{
+			name:   "struct",
+			fields: fields{URI: net_url.URL{Scheme: "http", Host: "localhost"}},
+			args: args{params: struct {
+				Query string    `query:"q"`
+				Page  int       `query:"page,omitempty"`
+				Since time.Time `query:"since"`
+			}{Query: "go", Since: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}},
+			want: Demand{URI: net_url.URL{Scheme: "http", Host: "localhost", RawQuery: "q=go&since=2024-05-01T00%3A00%3A00Z"}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -248,3 +280,132 @@ func TestDemand_Parameter(t *testing.T) {
 		})
 	}
 }
+
+func TestDemand_ParameterWith(t *testing.T) {
+	type search struct {
+		Tags   []string `query:"tags"`
+		Filter struct {
+			Lang string `query:"lang"`
+		} `query:"filter"`
+	}
+	value := search{Tags: []string{"a", "b"}}
+	value.Filter.Lang = "en"
+
+	tests := []struct {
+		name      string
+		encoder   ValuesEncoder
+		params    any
+		wantQuery string
+		wantErr   error
+	}{
+		{
+			name:      "repeat",
+			encoder:   ValuesEncoder{Tag: "query", Nesting: NESTING_BRACKET},
+			params:    value,
+			wantQuery: "filter[lang]=en&tags=a&tags=b",
+		},
+		{
+			name:      "comma",
+			encoder:   ValuesEncoder{Tag: "query", Nesting: NESTING_BRACKET, Arrays: ARRAY_COMMA},
+			params:    &value,
+			wantQuery: "filter[lang]=en&tags=a,b",
+		},
+		{
+			name:      "bracket",
+			encoder:   ValuesEncoder{Tag: "query", Nesting: NESTING_DOT, Arrays: ARRAY_BRACKET},
+			params:    value,
+			wantQuery: "filter.lang=en&tags[]=a&tags[]=b",
+		},
+		{
+			name:    "nil",
+			encoder: ValuesEncoder{Tag: "query"},
+			params:  nil,
+			wantErr: ErrDemandParamEmpty,
+		},
+		{
+			name:    "unsupported type",
+			encoder: ValuesEncoder{Tag: "query"},
+			params:  []string{"a"},
+			wantErr: ErrValuesUnsupported,
+		},
+		{
+			name:    "unsupported value",
+			encoder: ValuesEncoder{Tag: "query"},
+			params:  map[string]any{"f": func() {}},
+			wantErr: ErrValuesUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildDemand(http.MethodGet, "http://localhost", "").ParameterWith(tt.encoder, tt.params)
+			if !errors.Is(got.Error, tt.wantErr) {
+				t.Fatalf("Demand.ParameterWith() error = %v, want %v", got.Error, tt.wantErr)
+			}
+			if query, _ := net_url.QueryUnescape(got.URI.RawQuery); query != tt.wantQuery {
+				t.Errorf("Demand.ParameterWith() query = %s, want %s", query, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestDemand_Context(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expiring, cancelExpiring := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelExpiring()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantErr     error
+		wantRetries int
+	}{
+		{
+			name:    "nil context",
+			ctx:     nil,
+			wantErr: ErrDemandContextNil,
+		},
+		{
+			name:        "canceled before sending",
+			ctx:         canceled,
+			wantErr:     context.Canceled,
+			wantRetries: 1,
+		},
+		{
+			name:        "canceled while waiting between retries",
+			ctx:         expiring,
+			wantRetries: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := BuildDemand(http.MethodGet, url, "").Context(tt.ctx)
+			if tt.wantRetries == 0 {
+				if !errors.Is(d.Error, tt.wantErr) {
+					t.Errorf("Demand.Context() error = %v, want %v", d.Error, tt.wantErr)
+				}
+				return
+			}
+
+			start := time.Now()
+			_, props, ok := New(MAX_TIMEOUT, []time.Duration{time.Hour, time.Hour}).Send(d)
+			if ok {
+				t.Fatal("request.Send() succeeded, want failure")
+			}
+			if elapsed := time.Since(start); elapsed > time.Minute {
+				t.Errorf("request.Send() waits %v after context is done", elapsed)
+			}
+			if props.Retries != tt.wantRetries {
+				t.Errorf("request.Send() retries = %d, want %d", props.Retries, tt.wantRetries)
+			}
+			if tt.wantErr != nil && !errors.Is(LastError(props.Errors), tt.wantErr) {
+				t.Errorf("request.Send() errors = %v, want %v", props.Errors, tt.wantErr)
+			}
+		})
+	}
+}
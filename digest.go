@@ -0,0 +1,288 @@
+package request
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Digest algorithm of content digest, named as in RFC 9530
+type Digest string
+
+const (
+	DIGEST_SHA256 Digest = "sha-256"
+	DIGEST_SHA512 Digest = "sha-512"
+)
+
+// Integrity verification of response content and digest of request content
+type Integrity struct {
+	// Algorithm algorithm of Expected
+	Algorithm Digest
+
+	// Expected expected checksum of response content, nil means no verification
+	Expected []byte
+
+	// Headers verify response content against Content-Digest and Repr-Digest headers
+	// gzip is negotiated explicitly if Compression.Accept is empty, so content is verified as received before decompression
+	Headers bool
+
+	// Attach algorithm to compute Content-Digest header of request body, empty means no header
+	Attach Digest
+}
+
+// DigestError mismatch between expected and actual digest of content
+type DigestError struct {
+	// Algorithm digest algorithm
+	Algorithm Digest
+
+	// Source where expected digest comes from, e.g. "checksum", "Content-Digest", "Repr-Digest"
+	Source string
+
+	// Expected expected digest
+	Expected []byte
+
+	// Actual digest of received content
+	Actual []byte
+}
+
+// digester computes digests of content in several algorithms at once
+type digester map[Digest]hash.Hash
+
+// verifyingReader verifies digests of streaming body on EOF
+type verifyingReader struct {
+	io.ReadCloser
+	digester digester
+	expected []expectedDigest
+}
+
+// expectedDigest an expected digest and where it comes from
+type expectedDigest struct {
+	algorithm Digest
+	source    string
+	value     []byte
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Checksum verify response content against expected checksum, checksum is hex encoded
+// a mismatch fails the attempt with DigestError, so it is retried
+func (c Demand) Checksum(algorithm Digest, checksum string) Demand {
+	if newHash(algorithm) == nil {
+		c.Error = errors.Join(c.Error, ErrDigestUnsupported)
+		return c
+	}
+	expected, err := hex.DecodeString(checksum)
+	if err != nil || len(expected) == 0 {
+		c.Error = errors.Join(c.Error, ErrDemandChecksumInvalid)
+		return c
+	}
+	c.Integrity.Algorithm = algorithm
+	c.Integrity.Expected = expected
+	return c
+}
+
+// VerifyDigest verify response content against RFC 9530 Content-Digest and Repr-Digest headers
+// a mismatch fails the attempt with DigestError, so it is retried
+func (c Demand) VerifyDigest() Demand {
+	c.Integrity.Headers = true
+	return c
+}
+
+// AttachDigest compute and attach RFC 9530 Content-Digest header to request body
+// only encoded and in-memory bodies are digested, streamed bodies (SendUpload, SendMultipart, readers of SendRaw) are not
+func (c Demand) AttachDigest(algorithm Digest) Demand {
+	if newHash(algorithm) == nil {
+		c.Error = errors.Join(c.Error, ErrDigestUnsupported)
+		return c
+	}
+	c.Integrity.Attach = algorithm
+	return c
+}
+
+// Error implements error
+func (e *DigestError) Error() string {
+	return fmt.Sprintf("%s: %s %s is %x, want %x", ErrDigestMismatch, e.Source, e.Algorithm, e.Actual, e.Expected)
+}
+
+// Is report whether target is ErrDigestMismatch
+func (e *DigestError) Is(target error) bool {
+	return target == ErrDigestMismatch
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// attach add Content-Digest header of body to demand
+func (i Integrity) attach(c Demand, body []byte) Demand {
+	if i.Attach == "" {
+		return c
+	}
+	d := newDigester(i.Attach)
+	d.Write(body)
+	return c.Header("Content-Digest", formatDigest(i.Attach, d.sum(i.Attach)))
+}
+
+// headers collect expected digests of Content-Digest and Repr-Digest headers
+// Repr-Digest applies to the whole representation, so it is skipped for partial content
+func (i Integrity) headers(status int, header http.Header) []expectedDigest {
+	if !i.Headers {
+		return nil
+	}
+	expected := parseDigest("Content-Digest", header.Get("Content-Digest"))
+	if status != http.StatusPartialContent {
+		expected = append(expected, parseDigest("Repr-Digest", header.Get("Repr-Digest"))...)
+	}
+	return expected
+}
+
+// checksum collect expected checksum of decoded content
+// checksum applies to the whole content, so it is skipped for partial content
+func (i Integrity) checksum(status int) []expectedDigest {
+	if i.Expected == nil || status == http.StatusPartialContent {
+		return nil
+	}
+	return []expectedDigest{{algorithm: i.Algorithm, source: "checksum", value: i.Expected}}
+}
+
+// representation collect expected digests of the whole content, checksum and Repr-Digest
+func (i Integrity) representation(header http.Header) []expectedDigest {
+	expected := i.checksum(http.StatusOK)
+	if i.Headers {
+		expected = append(expected, parseDigest("Repr-Digest", header.Get("Repr-Digest"))...)
+	}
+	return expected
+}
+
+// verifying wrap body to verify expected digests on EOF
+func verifying(expected []expectedDigest, body io.ReadCloser) io.ReadCloser {
+	if len(expected) == 0 {
+		return body
+	}
+	return &verifyingReader{
+		ReadCloser: body,
+		digester:   newDigester(algorithms(expected)...),
+		expected:   expected,
+	}
+}
+
+// Read implements io.Reader
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	n, err := v.ReadCloser.Read(p)
+	v.digester.Write(p[:n])
+	if err == io.EOF {
+		if verifyErr := v.digester.verify(v.expected); verifyErr != nil {
+			return n, verifyErr
+		}
+	}
+	return n, err
+}
+
+// newDigester create a digester for supported algorithms
+func newDigester(algorithms ...Digest) digester {
+	d := make(digester)
+	for _, algorithm := range algorithms {
+		if h := newHash(algorithm); h != nil {
+			d[algorithm] = h
+		}
+	}
+	return d
+}
+
+// Write implements io.Writer
+func (d digester) Write(p []byte) (int, error) {
+	for _, h := range d {
+		h.Write(p)
+	}
+	return len(p), nil
+}
+
+// sum get digest of algorithm
+func (d digester) sum(algorithm Digest) []byte {
+	h, ok := d[algorithm]
+	if !ok {
+		return nil
+	}
+	return h.Sum(nil)
+}
+
+// verify compare computed digests with expected ones, unsupported algorithms are ignored
+func (d digester) verify(expected []expectedDigest) error {
+	for _, e := range expected {
+		actual := d.sum(e.algorithm)
+		if actual == nil {
+			continue
+		}
+		if !bytes.Equal(actual, e.value) {
+			return &DigestError{
+				Algorithm: e.algorithm,
+				Source:    e.source,
+				Expected:  e.value,
+				Actual:    actual,
+			}
+		}
+	}
+	return nil
+}
+
+// newHash create hash of algorithm, nil if unsupported
+func newHash(algorithm Digest) hash.Hash {
+	switch algorithm {
+	case DIGEST_SHA256:
+		return sha256.New()
+	case DIGEST_SHA512:
+		return sha512.New()
+	default:
+		return nil
+	}
+}
+
+// algorithms get algorithms of expected digests
+func algorithms(expected []expectedDigest) []Digest {
+	result := make([]Digest, 0, len(expected))
+	for _, e := range expected {
+		result = append(result, e.algorithm)
+	}
+	return result
+}
+
+// formatDigest format digest as RFC 9530 dictionary member, e.g. sha-256=:base64:
+func formatDigest(algorithm Digest, sum []byte) string {
+	return fmt.Sprintf("%s=:%s:", algorithm, base64.StdEncoding.EncodeToString(sum))
+}
+
+// parseDigest parse RFC 9530 digest header, e.g. "sha-256=:base64:, sha-512=:base64:"
+// invalid members are ignored
+func parseDigest(source string, value string) []expectedDigest {
+	var expected []expectedDigest
+	for _, member := range strings.Split(value, ",") {
+		name, encoded, found := strings.Cut(strings.TrimSpace(member), "=")
+		if !found {
+			continue
+		}
+		encoded = strings.TrimSpace(encoded)
+		if len(encoded) < 2 || encoded[0] != ':' || encoded[len(encoded)-1] != ':' {
+			continue
+		}
+		sum, err := base64.StdEncoding.DecodeString(encoded[1 : len(encoded)-1])
+		if err != nil {
+			continue
+		}
+		expected = append(expected, expectedDigest{
+			algorithm: Digest(strings.ToLower(strings.TrimSpace(name))),
+			source:    source,
+			value:     sum,
+		})
+	}
+	return expected
+}
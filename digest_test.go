@@ -0,0 +1,143 @@
+package request
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func TestDemand_Checksum(t *testing.T) {
+	content := []byte("integrity matters")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/digest":
+			if r.Header.Get("Range") == "" {
+				w.Header().Set("Content-Digest", formatDigest(DIGEST_SHA256, sum[:]))
+			}
+		case "/corrupted":
+			w.Header().Set("Repr-Digest", formatDigest(DIGEST_SHA256, make([]byte, sha256.Size)))
+		case "/echo":
+			w.Write([]byte(r.Header.Get("Content-Digest")))
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		demand      Demand
+		wantRetries int
+		wantErr     error
+	}{
+		{
+			name:        "checksum",
+			demand:      BuildDemand(http.MethodGet, server.URL, "/").Checksum(DIGEST_SHA256, checksum),
+			wantRetries: 1,
+		},
+		{
+			name:        "checksum mismatch",
+			demand:      BuildDemand(http.MethodGet, server.URL, "/").Checksum(DIGEST_SHA256, "00ff"),
+			wantRetries: 2,
+			wantErr:     ErrDigestMismatch,
+		},
+		{
+			name:        "content digest",
+			demand:      BuildDemand(http.MethodGet, server.URL, "/digest").VerifyDigest(),
+			wantRetries: 1,
+		},
+		{
+			name:        "repr digest mismatch",
+			demand:      BuildDemand(http.MethodGet, server.URL, "/corrupted").VerifyDigest(),
+			wantRetries: 2,
+			wantErr:     ErrDigestMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(MAX_TIMEOUT, []time.Duration{0, 0})
+			_, props, ok := r.Send(tt.demand)
+			if ok != (tt.wantErr == nil) || !errors.Is(LastError(props.Errors), tt.wantErr) {
+				t.Fatalf("request.Send() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+			}
+			if props.Retries != tt.wantRetries {
+				t.Errorf("Properties.Retries = %v, want %v", props.Retries, tt.wantRetries)
+			}
+			var digestErr *DigestError
+			if tt.wantErr != nil && !errors.As(LastError(props.Errors), &digestErr) {
+				t.Errorf("error %T is not *DigestError", LastError(props.Errors))
+			}
+
+			path := filepath.Join(t.TempDir(), "content")
+			_, props, ok = r.Download(tt.demand, Target{Path: path, Segments: 2})
+			if ok != (tt.wantErr == nil) || !errors.Is(LastError(props.Errors), tt.wantErr) {
+				t.Fatalf("request.Download() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+			}
+		})
+	}
+
+	got, _, _ := New(MAX_TIMEOUT, nil).SendJson(BuildDemand(http.MethodPost, server.URL, "/echo").AttachDigest(DIGEST_SHA256), "payload")
+	body := sha256.Sum256([]byte(`"payload"`))
+	if want := formatDigest(DIGEST_SHA256, body[:]); string(got.Body) != want {
+		t.Errorf("Content-Digest = %q, want %q", got.Body, want)
+	}
+}
+
+func TestDemand_VerifyDigest_compressed(t *testing.T) {
+	content := []byte("integrity matters")
+
+	var encoded bytes.Buffer
+	writer := gzip.NewWriter(&encoded)
+	writer.Write(content)
+	writer.Close()
+	sum := sha256.Sum256(encoded.Bytes())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write(content)
+			return
+		}
+		switch r.URL.Path {
+		case "/digest":
+			w.Header().Set("Content-Digest", formatDigest(DIGEST_SHA256, sum[:]))
+		case "/corrupted":
+			w.Header().Set("Content-Digest", formatDigest(DIGEST_SHA256, make([]byte, sha256.Size)))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(encoded.Bytes())
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{name: "content digest", path: "/digest"},
+		{name: "content digest mismatch", path: "/corrupted", wantErr: ErrDigestMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, props, ok := New(MAX_TIMEOUT, nil).Send(BuildDemand(http.MethodGet, server.URL, tt.path).VerifyDigest())
+			if ok != (tt.wantErr == nil) || !errors.Is(LastError(props.Errors), tt.wantErr) {
+				t.Fatalf("request.Send() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+			}
+			if tt.wantErr == nil && (!bytes.Equal(got.Body, content) || got.ContentEncoding != "gzip") {
+				t.Errorf("Result.Body = %q, ContentEncoding = %q, want decompressed content", got.Body, got.ContentEncoding)
+			}
+		})
+	}
+}
@@ -0,0 +1,453 @@
+package request
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Target destination of download
+type Target struct {
+	// Path file path to write downloaded content
+	Path string
+
+	// Progress called while content is received, total is -1 when size is unknown
+	Progress func(received int64, total int64)
+
+	// Segments number of ranges to download concurrently, zero or one means a single stream
+	// content is downloaded in a single stream if server does not support ranges,
+	// support of ranges is probed by a request of the first byte, counted in Properties as an attempt,
+	// Properties aggregate attempts of all segments: Retries counts them, Timings are in order of completion
+	Segments int
+
+	// IdleTimeout maximum time to wait for data of response body, zero means timeout of Request
+	// a stalled attempt is aborted with ErrDownloadIdle and resumed on next attempt
+	IdleTimeout time.Duration
+}
+
+// download state of a download across attempts
+type download struct {
+	mutex     *sync.Mutex
+	file      *os.File
+	offset    int64
+	total     int64
+	received  int64
+	validator string
+	resumed   int64
+	idle      time.Duration
+	progress  func(received int64, total int64)
+}
+
+// idleReader fails with ErrDownloadIdle when no data is received within timeout
+// the stream is aborted when timeout expires, so a stalled read returns
+type idleReader struct {
+	stream  io.Reader
+	timeout time.Duration
+	timer   *time.Timer
+	expired atomic.Bool
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Download download response body into a file
+// content is written to a temporary file next to target path and renamed on success,
+// Result describes the whole content with status 200 even if it is received in ranges,
+// failed attempts are resumed using Range and If-Range headers,
+// client error statuses, except 408 (Request Timeout) and 429 (Too Many Requests), are not retried,
+// size of content is validated against Content-Length
+func (r request) Download(c Demand, t Target) (result Result, response Properties, isSuccess bool) {
+	if c.Error != nil {
+		isSuccess = false
+		return //↩️ ∅
+	}
+
+	start := time.Now()
+	defer func(start time.Time) {
+		response.TotalElapsed = time.Since(start)
+	}(start)
+
+	file, err := createPart(t.Path)
+	if err != nil {
+		response.Errors = append(response.Errors, err)
+		isSuccess = false
+		return //↩️ ∅
+	}
+	defer func() {
+		file.Close()
+		if !isSuccess {
+			os.Remove(file.Name())
+		}
+	}()
+
+	if len(r.Retries) == 0 {
+		r.Retries = []time.Duration{0}
+	}
+
+	var (
+		handler = r.handler()
+		state   = &download{
+			mutex:    &sync.Mutex{},
+			file:     file,
+			total:    -1,
+			idle:     cmp.Or(t.IdleTimeout, r.Timeout),
+			progress: t.Progress,
+		}
+	)
+
+	c.stream = true
+
+	ranges := false
+	if t.Segments > 1 {
+		var probeErr error
+		ranges, probeErr = state.probe(handler, c, t.Segments, &response)
+		defer func() {
+			// download falls back to a single stream, so failure of probe is reported only if download fails
+			if probeErr != nil && !isSuccess {
+				response.Errors = append([]error{probeErr}, response.Errors...)
+			}
+		}()
+	}
+
+	if ranges {
+		result, err = state.segmented(r.Retries, handler, c, t.Segments, &response)
+		if err == nil {
+			if err = state.verify(c.Integrity, result); err != nil {
+				// corrupted content, download again in a single stream
+				response.Errors = append(response.Errors, err)
+				if err = state.reset(); err == nil {
+					result, err = state.sequential(r.Retries, handler, c, &response)
+				}
+			}
+		}
+	} else {
+		result, err = state.sequential(r.Retries, handler, c, &response)
+	}
+	if err != nil {
+		isSuccess = false
+		return //↩️ ∅
+	}
+
+	if err = state.finish(t.Path); err != nil {
+		response.Errors = append(response.Errors, err)
+		isSuccess = false
+		return //↩️ ∅
+	}
+
+	result = state.whole(result)
+	isSuccess = true
+	return //↩️ ∅
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// sequential download content in a single stream, resuming from current offset on retries
+func (d *download) sequential(retries []time.Duration, handler Handler, c Demand, response *Properties) (result Result, err error) {
+	for _, duration := range retries {
+		response.Retries++
+
+		demand := c
+		if d.offset > 0 {
+			demand = d.ranged(demand, d.offset, -1)
+		}
+
+		begin := time.Now()
+		demand.tracer = newTracer()
+		result, err = d.attempt(handler, demand, response)
+		response.Elapsed = time.Since(begin)
+		response.Timings = append(response.Timings, demand.tracer.snapshot())
+
+		if err == nil {
+			if err = d.verify(c.Integrity, result); err != nil {
+				// corrupted content, start over on next attempt
+				if resetErr := d.reset(); resetErr != nil {
+					err = errors.Join(err, resetErr)
+				}
+			}
+		}
+		if err == nil {
+			return result, nil
+		}
+
+		response.Errors = append(response.Errors, err)
+
+		if terminal(err) || permanent(result.StatusCode) || wait(c.requestContext(), duration) != nil {
+			break
+		}
+	}
+
+	// All retries failed
+	return result, err
+}
+
+// attempt perform an attempt of download, appending content to file from current offset
+func (d *download) attempt(handler Handler, c Demand, response *Properties) (Result, error) {
+	result, err := handler(c, nil)
+	if err != nil {
+		return result, err
+	}
+	defer result.Stream.Close()
+	stream := idle(result.Stream, d.idle)
+	defer stream.stop()
+	result.Stream = nil
+
+	switch result.StatusCode {
+	case http.StatusPartialContent:
+		first, total, err := parseContentRange(result.Header.Get("Content-Range"))
+		if err != nil {
+			return result, err
+		}
+		if first != d.offset {
+			err := fmt.Errorf("%w: range starts at %d, want %d", ErrDownloadRange, first, d.offset)
+			// unexpected range, start over on next attempt
+			d.offset = 0
+			return result, err
+		}
+		if total >= 0 {
+			d.total = total
+		}
+		// bytes kept by previous resumes are not counted again
+		response.Resumed += max(d.offset-d.resumed, 0)
+		d.resumed = d.offset
+	case http.StatusOK:
+		// full content, server does not support range or content has changed
+		if err := d.reset(); err != nil {
+			return result, err
+		}
+		d.total = -1
+		if result.ContentEncoding == "" {
+			// Content-Length of an encoded response is not size of decoded content
+			d.total = contentLength(result.Header)
+		}
+		d.validator = validator(result.Header)
+	default:
+		return result, fmt.Errorf("%w: %d", ErrDownloadStatus, result.StatusCode)
+	}
+
+	if _, err := d.file.Seek(d.offset, io.SeekStart); err != nil {
+		return result, err
+	}
+
+	written, err := io.Copy(d, stream)
+	response.Transferred += written
+	if errors.Is(err, ErrDigestMismatch) {
+		// corrupted content, start over on next attempt
+		return result, errors.Join(err, d.reset())
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if d.total >= 0 && d.offset != d.total {
+		return result, fmt.Errorf("%w: received %d of %d bytes", ErrDownloadIncomplete, d.offset, d.total)
+	}
+
+	return result, nil
+}
+
+// Write implements io.Writer, write into file from current offset and report progress
+func (d *download) Write(p []byte) (int, error) {
+	n, err := d.file.Write(p)
+	d.offset += int64(n)
+	d.report(int64(n))
+	return n, err
+}
+
+// report add n received bytes and report progress, negative n discards received bytes
+func (d *download) report(n int64) {
+	if n == 0 {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.received += n
+	if d.progress != nil {
+		d.progress(d.received, d.total)
+	}
+}
+
+// whole make result of entire content from result of a partial content response
+func (d *download) whole(result Result) Result {
+	if result.StatusCode != http.StatusPartialContent {
+		return result
+	}
+	size := d.total
+	if size < 0 {
+		size = d.offset
+	}
+	result.StatusCode = http.StatusOK
+	result.IsOK = true
+	result.ContentLength = size
+	result.Header = result.Header.Clone()
+	result.Header.Del("Content-Range")
+	result.Header.Set("Content-Length", strconv.FormatInt(size, 10))
+	return result
+}
+
+// idle watch stream to fail with ErrDownloadIdle when no data is received within timeout
+func idle(stream io.ReadCloser, timeout time.Duration) *idleReader {
+	abort := func() { stream.Close() }
+	if body, ok := stream.(*streamBody); ok {
+		// canceling request unblocks a pending read
+		abort = body.cancel
+	}
+
+	i := &idleReader{stream: stream, timeout: timeout}
+	i.timer = time.AfterFunc(timeout, func() {
+		i.expired.Store(true)
+		abort()
+	})
+	return i
+}
+
+// Read implements io.Reader
+func (i *idleReader) Read(p []byte) (int, error) {
+	n, err := i.stream.Read(p)
+	if i.expired.Load() {
+		return n, fmt.Errorf("%w: no data for %v", ErrDownloadIdle, i.timeout)
+	}
+	i.timer.Reset(i.timeout)
+	return n, err
+}
+
+// stop stop watching stream
+func (i *idleReader) stop() {
+	i.timer.Stop()
+}
+
+// ranged add Range and If-Range headers to demand
+// content coding is not negotiated, since ranges of an encoded response are slices of the encoded content
+// last is -1 for open ended range
+func (d *download) ranged(c Demand, first int64, last int64) Demand {
+	c = c.Header("Accept-Encoding", "identity")
+	if last < 0 {
+		c = c.Header("Range", fmt.Sprintf("bytes=%d-", first))
+	} else {
+		c = c.Header("Range", fmt.Sprintf("bytes=%d-%d", first, last))
+	}
+	if d.validator != "" {
+		c = c.Header("If-Range", d.validator)
+	}
+	return c
+}
+
+// verify verify whole downloaded file against checksum and Repr-Digest
+// Repr-Digest is skipped if content has been decompressed
+func (d *download) verify(i Integrity, result Result) error {
+	header := result.Header
+	if result.ContentEncoding != "" {
+		header = nil
+	}
+	expected := i.representation(header)
+	if len(expected) == 0 {
+		return nil
+	}
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	digester := newDigester(algorithms(expected)...)
+	if _, err := io.Copy(digester, d.file); err != nil {
+		return err
+	}
+	return digester.verify(expected)
+}
+
+// reset truncate file to start over
+func (d *download) reset() error {
+	d.mutex.Lock()
+	d.received = 0
+	d.mutex.Unlock()
+	d.offset = 0
+	d.resumed = 0
+	return d.file.Truncate(0)
+}
+
+// finish close temporary file and move it to path
+func (d *download) finish(path string) error {
+	if err := d.file.Sync(); err != nil {
+		return err
+	}
+	if err := d.file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(d.file.Name(), path)
+}
+
+// createPart create temporary file next to path to download into
+// it is created with mode 0666 before umask, same as os.Create, since it is renamed to path
+func createPart(path string) (*os.File, error) {
+	var err error
+	for range 10000 {
+		name := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+"."+strconv.FormatUint(uint64(rand.Uint32()), 36)+".part")
+		var file *os.File
+		file, err = os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o666)
+		if !errors.Is(err, fs.ErrExist) {
+			return file, err
+		}
+	}
+	return nil, err
+}
+
+// permanent report whether status of download fails the same on retry, client errors except 408 and 429
+func permanent(status int) bool {
+	return status >= 400 && status < 500 && status != http.StatusRequestTimeout && status != http.StatusTooManyRequests
+}
+
+// validator get strong validator of response for If-Range header
+func validator(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+// contentLength get Content-Length header, -1 when it is unknown
+func contentLength(header http.Header) int64 {
+	length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return length
+}
+
+// parseContentRange parse Content-Range header, e.g. "bytes 100-199/200"
+// total is -1 when size is unknown
+func parseContentRange(value string) (first int64, total int64, err error) {
+	rangeValue, found := strings.CutPrefix(value, "bytes ")
+	if !found {
+		return 0, 0, fmt.Errorf("%w: %q", ErrDownloadRange, value)
+	}
+	span, size, found := strings.Cut(rangeValue, "/")
+	if !found {
+		return 0, 0, fmt.Errorf("%w: %q", ErrDownloadRange, value)
+	}
+	firstValue, _, found := strings.Cut(span, "-")
+	if !found {
+		return 0, 0, fmt.Errorf("%w: %q", ErrDownloadRange, value)
+	}
+
+	if first, err = strconv.ParseInt(firstValue, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("%w: %q", ErrDownloadRange, value)
+	}
+	total = -1
+	if size != "*" {
+		if total, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("%w: %q", ErrDownloadRange, value)
+		}
+	}
+	return first, total, nil
+}
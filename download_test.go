@@ -0,0 +1,205 @@
+package request
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_Download(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		switch r.URL.Path {
+		case "/interrupted":
+			if r.Header.Get("Range") == "" {
+				// drop connection in the middle of content
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				w.Write(content[:4000])
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+		case "/interrupted-twice":
+			switch r.Header.Get("Range") {
+			case "":
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				w.Write(content[:4000])
+			case "bytes=4000-":
+				w.Header().Set("Content-Range", "bytes 4000-9999/10000")
+				w.Header().Set("Content-Length", "6000")
+				w.WriteHeader(http.StatusPartialContent)
+				w.Write(content[4000:7000])
+			default:
+				http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+				return
+			}
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		case "/stalled":
+			if r.Header.Get("Range") == "" {
+				// stop sending in the middle of content without closing connection
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				w.Write(content[:4000])
+				w.(http.Flusher).Flush()
+				<-r.Context().Done()
+				return
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+		case "/plain":
+			w.Write(content)
+		case "/probe-fails":
+			if r.Header.Get("Range") != "" {
+				panic(http.ErrAbortHandler)
+			}
+			w.Write(content)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		path            string
+		segments        int
+		idle            time.Duration
+		wantTransferred int64
+		wantResumed     int64
+		wantRetries     int
+		wantErr         error
+	}{
+		{
+			name:            "complete",
+			path:            "/complete",
+			wantTransferred: 10000,
+		},
+		{
+			name:            "resumed",
+			path:            "/interrupted",
+			wantTransferred: 10000,
+			wantResumed:     4000,
+		},
+		{
+			name:            "resumed after stall",
+			path:            "/stalled",
+			idle:            50 * time.Millisecond,
+			wantTransferred: 10000,
+			wantResumed:     4000,
+		},
+		{
+			name:            "resumed twice",
+			path:            "/interrupted-twice",
+			wantTransferred: 10000,
+			wantResumed:     7000,
+		},
+		{
+			name:            "segmented",
+			path:            "/complete",
+			segments:        4,
+			wantTransferred: 10000,
+		},
+		{
+			name:            "segmented fallback",
+			path:            "/plain",
+			segments:        4,
+			wantTransferred: 10000,
+		},
+		{
+			name:            "segmented fallback on probe failure",
+			path:            "/probe-fails",
+			segments:        4,
+			wantTransferred: 10000,
+		},
+		{
+			name:        "status",
+			path:        "/missing",
+			wantRetries: 1,
+			wantErr:     ErrDownloadStatus,
+		},
+		{
+			name:        "retryable status",
+			path:        "/unavailable",
+			wantRetries: 3,
+			wantErr:     ErrDownloadStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "artifact.bin")
+			var received int64
+			target := Target{
+				Path:        path,
+				Progress:    func(r int64, total int64) { received = r },
+				Segments:    tt.segments,
+				IdleTimeout: tt.idle,
+			}
+
+			r := New(MAX_TIMEOUT, []time.Duration{0, 0, 0})
+			result, props, ok := r.Download(BuildDemand(http.MethodGet, server.URL, tt.path), target)
+			if tt.wantErr != nil {
+				if ok || !errors.Is(LastError(props.Errors), tt.wantErr) {
+					t.Fatalf("request.Download() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+				}
+				if props.Retries != tt.wantRetries {
+					t.Errorf("Properties.Retries = %v, want %v", props.Retries, tt.wantRetries)
+				}
+				if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 0 {
+					t.Errorf("temporary files left: %v", entries)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("request.Download() errors = %v", props.Errors)
+			}
+
+			if tt.idle > 0 && !errors.Is(LastError(props.Errors), ErrDownloadIdle) {
+				t.Errorf("Properties.Errors = %v, want %v", props.Errors, ErrDownloadIdle)
+			}
+			if tt.wantResumed == 0 && len(props.Errors) != 0 {
+				t.Errorf("Properties.Errors = %v, want none", props.Errors)
+			}
+			if props.Retries != len(props.Timings) {
+				t.Errorf("Properties.Retries = %v, want %v as Timings", props.Retries, len(props.Timings))
+			}
+			if result.StatusCode != http.StatusOK || result.Header.Get("Content-Range") != "" ||
+				(result.ContentLength >= 0 && result.ContentLength != int64(len(content))) {
+				t.Errorf("Result status = %v, length = %v, want whole content", result.StatusCode, result.ContentLength)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil || !bytes.Equal(got, content) {
+				t.Fatalf("downloaded file differs, error = %v", err)
+			}
+			reference, err := os.Create(filepath.Join(t.TempDir(), "reference"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			reference.Close()
+			info, _ := os.Stat(path)
+			referenceInfo, _ := os.Stat(reference.Name())
+			if info.Mode() != referenceInfo.Mode() {
+				t.Errorf("file mode = %v, want %v as os.Create", info.Mode(), referenceInfo.Mode())
+			}
+			if received != int64(len(content)) {
+				t.Errorf("Progress received = %v, want %v", received, len(content))
+			}
+			if props.Transferred != tt.wantTransferred || props.Resumed != tt.wantResumed {
+				t.Errorf("Properties Transferred = %v, Resumed = %v, want %v, %v", props.Transferred, props.Resumed, tt.wantTransferred, tt.wantResumed)
+			}
+		})
+	}
+}
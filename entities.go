@@ -1,6 +1,10 @@
 package request
 
-import "time"
+import (
+	"io"
+	"net/http"
+	"time"
+)
 
 //──────────────────────────────────────────────────────────────────────────────────────────────────
 
@@ -9,16 +13,57 @@ type Result struct {
 	// Body represents the response body.
 	Body []byte
 
+	// Stream represents the response body to read, only set by (Request).Stream
+	// caller is responsible for closing it
+	Stream io.ReadCloser
+
 	// BodyObject represents the response body marshaled as `map[string]any`,
-	// nil if the body is not a valid JSON
+	// nil if the body is not a JSON object
 	BodyObject map[string]any
 
+	// BodyValue represents the response body marshaled as any JSON value: object, array, string, number, bool,
+	// numbers are `float64`, or `json.Number` to keep precision if JsonOptions.UseNumber is set,
+	// nil if the body is not a valid JSON
+	BodyValue any
+
 	// StatusCode http status code, e.g. http.StatusOK
 	StatusCode int
 
+	// Header response headers
+	Header http.Header
+
+	// Trailer response trailers, filled once body is read entirely
+	Trailer http.Header
+
+	// ContentLength length of response body as received, -1 if unknown
+	ContentLength int64
+
+	// Protocol negotiated protocol, e.g. "HTTP/1.1", "HTTP/2.0"
+	Protocol string
+
+	// URL final URL of response after redirects
+	URL string
+
+	// RemoteAddr address of server which sent response
+	RemoteAddr string
+
+	// ContentEncoding original content coding of response body, e.g. "gzip", empty if not encoded
+	// Body and Stream are already decompressed if coding is negotiated and supported (gzip, deflate),
+	// body of any other coding is passed through raw as received
+	ContentEncoding string
+
 	// IsOK is status ok
 	// Indeed does response got http.StatusOK
 	IsOK bool
+
+	// Redirects chain of redirect hops followed to reach the response
+	Redirects []RedirectHop
+
+	// Cookies set by the response
+	Cookies []*http.Cookie
+
+	// json options of decoding JSON body, refer to Decode
+	json JsonOptions
 }
 
 // Properties of perfoming request
@@ -34,4 +79,14 @@ type Properties struct {
 
 	// Errors contains all errors that occurred during the request
 	Errors []error
+
+	// Timings connection phases of each attempt
+	Timings []Timing
+
+	// Transferred number of bytes received by download
+	Transferred int64
+
+	// Resumed number of bytes kept from previous attempts of download instead of receiving again,
+	// each byte is counted once however many times download is resumed
+	Resumed int64
 }
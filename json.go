@@ -0,0 +1,110 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// JsonOptions options of JSON encoding of SendJson and decoding of JSON responses
+type JsonOptions struct {
+	// DisableHTMLEscape do not escape <, > and & in strings
+	DisableHTMLEscape bool
+
+	// Indent indentation of each level, empty means compact
+	Indent string
+
+	// Marshal custom encoder, e.g. of another JSON library, it takes precedence over DisableHTMLEscape and Indent
+	Marshal func(v any) ([]byte, error)
+
+	// DisallowUnknownFields fail decoding if object has keys which do not match any struct field
+	DisallowUnknownFields bool
+
+	// UseNumber decode numbers into interface{} as json.Number instead of float64
+	UseNumber bool
+
+	// Unmarshal custom decoder, e.g. of another JSON library, it takes precedence over DisallowUnknownFields and UseNumber
+	Unmarshal func(data []byte, v any) error
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// JsonOptions set options of JSON encoding and decoding for all demands
+func (r request) JsonOptions(options JsonOptions) Request {
+	r.Json = options
+	return r
+}
+
+// JsonOptions set options of JSON encoding and decoding, it overrides (Request).JsonOptions
+func (c Demand) JsonOptions(options JsonOptions) Demand {
+	c.Json = &options
+	return c
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// json get effective JSON options of demand
+func (r request) json(c Demand) JsonOptions {
+	if c.Json != nil {
+		return *c.Json
+	}
+	return r.Json
+}
+
+// marshal encode v as JSON, falls back to codec of HTTP_JSON if there is no encoding option
+func (o JsonOptions) marshal(v any) ([]byte, error) {
+	switch {
+	case o.Marshal != nil:
+		return o.Marshal(v)
+	case o.DisableHTMLEscape || o.Indent != "":
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(!o.DisableHTMLEscape)
+		encoder.SetIndent("", o.Indent)
+		if err := encoder.Encode(v); err != nil {
+			return nil, err
+		}
+		return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+	default:
+		encode, err := encoder(string(HTTP_JSON))
+		if err != nil {
+			return nil, err
+		}
+		return encode(v)
+	}
+}
+
+// decoding report whether there is any decoding option
+func (o JsonOptions) decoding() bool {
+	return o.Unmarshal != nil || o.DisallowUnknownFields || o.UseNumber
+}
+
+// unmarshal decode JSON data into v
+func (o JsonOptions) unmarshal(data []byte, v any) error {
+	switch {
+	case o.Unmarshal != nil:
+		return o.Unmarshal(data, v)
+	case o.decoding():
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if o.DisallowUnknownFields {
+			decoder.DisallowUnknownFields()
+		}
+		if o.UseNumber {
+			decoder.UseNumber()
+		}
+		if err := decoder.Decode(v); err != nil {
+			return err
+		}
+		if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+			return errors.New("invalid data after top-level value")
+		}
+		return nil
+	default:
+		return json.Unmarshal(data, v)
+	}
+}
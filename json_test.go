@@ -0,0 +1,149 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_JsonOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	payload := map[string]any{"html": "<b>&</b>", "n": 1}
+
+	tests := []struct {
+		name    string
+		request JsonOptions
+		demand  *JsonOptions
+		want    string
+	}{
+		{
+			name: "default",
+			want: `{"html":"\u003cb\u003e\u0026\u003c/b\u003e","n":1}`,
+		},
+		{
+			name:    "disable html escape",
+			request: JsonOptions{DisableHTMLEscape: true},
+			want:    `{"html":"<b>&</b>","n":1}`,
+		},
+		{
+			name:    "indent",
+			request: JsonOptions{Indent: "  "},
+			want:    "{\n  \"html\": \"\\u003cb\\u003e\\u0026\\u003c/b\\u003e\",\n  \"n\": 1\n}",
+		},
+		{
+			name: "custom marshal",
+			request: JsonOptions{DisableHTMLEscape: true, Marshal: func(v any) ([]byte, error) {
+				return []byte(`{"custom":true}`), nil
+			}},
+			want: `{"custom":true}`,
+		},
+		{
+			name:    "demand overrides request",
+			request: JsonOptions{Indent: "  "},
+			demand:  &JsonOptions{DisableHTMLEscape: true},
+			want:    `{"html":"<b>&</b>","n":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := BuildDemand(http.MethodPost, server.URL, "")
+			if tt.demand != nil {
+				d = d.JsonOptions(*tt.demand)
+			}
+			got, props, ok := New(MAX_TIMEOUT, nil).JsonOptions(tt.request).SendJson(d, payload)
+			if !ok {
+				t.Fatalf("request.SendJson() errors = %v", props.Errors)
+			}
+			if string(got.Body) != tt.want {
+				t.Errorf("request.SendJson() body = %s, want %s", got.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_JsonOptions(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":9007199254740993,"extra":true}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		request JsonOptions
+		demand  *JsonOptions
+		decode  func(Result) (any, error)
+		want    any
+		wantErr error
+	}{
+		{
+			name:   "default",
+			decode: func(res Result) (any, error) { return Decode[map[string]any](res) },
+			want:   map[string]any{"id": float64(9007199254740993), "extra": true},
+		},
+		{
+			name:    "use number",
+			request: JsonOptions{UseNumber: true},
+			decode:  func(res Result) (any, error) { return Decode[map[string]any](res) },
+			want:    map[string]any{"id": json.Number("9007199254740993"), "extra": true},
+		},
+		{
+			name:    "disallow unknown fields",
+			request: JsonOptions{DisallowUnknownFields: true},
+			decode:  func(res Result) (any, error) { return Decode[item](res) },
+			want:    item{ID: 9007199254740993},
+			wantErr: ErrDecodeBody,
+		},
+		{
+			name:    "demand overrides request",
+			request: JsonOptions{DisallowUnknownFields: true},
+			demand:  &JsonOptions{},
+			decode:  func(res Result) (any, error) { return Decode[item](res) },
+			want:    item{ID: 9007199254740993},
+		},
+		{
+			name: "custom unmarshal",
+			request: JsonOptions{Unmarshal: func(data []byte, v any) error {
+				v.(*item).ID = 7
+				return nil
+			}},
+			decode: func(res Result) (any, error) { return Decode[item](res) },
+			want:   item{ID: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := BuildDemand(http.MethodGet, server.URL, "")
+			if tt.demand != nil {
+				d = d.JsonOptions(*tt.demand)
+			}
+			result, props, ok := New(MAX_TIMEOUT, nil).JsonOptions(tt.request).Send(d)
+			if !ok {
+				t.Fatalf("request.Send() errors = %v", props.Errors)
+			}
+			got, err := tt.decode(result)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package request
+
+import (
+	"io"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// ResponseLimit maximum size of response body
+type ResponseLimit struct {
+	// Size maximum number of bytes of response body, zero means unlimited
+	Size int64
+
+	// KeepTruncated keep the first Size bytes in Result.Body when limit exceeded
+	KeepTruncated bool
+}
+
+// limitedReader fails with ErrResponseTooLarge when more than remaining bytes are read
+type limitedReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// MaxResponseSize set maximum size of response body for all demands
+// size zero means unlimited, exceeding limit fails with ErrResponseTooLarge and it is not retried
+// keepTruncated keep the first size bytes in Result.Body when limit exceeded
+func (r request) MaxResponseSize(size int64, keepTruncated bool) Request {
+	r.Limit = ResponseLimit{Size: size, KeepTruncated: keepTruncated}
+	return r
+}
+
+// MaxResponseSize set maximum size of response body, it overrides (Request).MaxResponseSize
+// size zero means using limit of Request
+// keepTruncated keep the first size bytes in Result.Body when limit exceeded
+func (c Demand) MaxResponseSize(size int64, keepTruncated bool) Demand {
+	c.Limit = ResponseLimit{Size: size, KeepTruncated: keepTruncated}
+	return c
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// limit get effective response limit of demand
+func (r request) limit(c Demand) ResponseLimit {
+	if c.Limit.Size > 0 {
+		return c.Limit
+	}
+	return r.Limit
+}
+
+// wrap wrap body to fail with ErrResponseTooLarge when limit exceeded
+func (l ResponseLimit) wrap(body io.ReadCloser) io.ReadCloser {
+	if l.Size <= 0 {
+		return body
+	}
+	return &limitedReader{ReadCloser: body, remaining: l.Size}
+}
+
+// Read implements io.Reader
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// probe for more data beyond the limit
+		var probe [1]byte
+		n, err := l.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,70 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_MaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		request  Request
+		demand   Demand
+		wantBody string
+		wantErr  error
+	}{
+		{
+			name:     "within limit",
+			request:  New(MAX_TIMEOUT, []time.Duration{0, 0}).MaxResponseSize(10, false),
+			demand:   BuildDemand(http.MethodGet, server.URL, ""),
+			wantBody: "0123456789",
+		},
+		{
+			name:    "exceeded",
+			request: New(MAX_TIMEOUT, []time.Duration{0, 0}).MaxResponseSize(4, false),
+			demand:  BuildDemand(http.MethodGet, server.URL, ""),
+			wantErr: ErrResponseTooLarge,
+		},
+		{
+			name:     "demand overrides and keeps truncated",
+			request:  New(MAX_TIMEOUT, []time.Duration{0, 0}).MaxResponseSize(100, false),
+			demand:   BuildDemand(http.MethodGet, server.URL, "").MaxResponseSize(4, true),
+			wantBody: "0123",
+			wantErr:  ErrResponseTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, props, ok := tt.request.Send(tt.demand)
+			if ok != (tt.wantErr == nil) || !errors.Is(LastError(props.Errors), tt.wantErr) {
+				t.Fatalf("request.Send() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+			}
+			if props.Retries != 1 {
+				t.Errorf("Properties.Retries = %d, want 1", props.Retries)
+			}
+			if string(got.Body) != tt.wantBody {
+				t.Errorf("Result.Body = %q, want %q", got.Body, tt.wantBody)
+			}
+
+			streamed, _, ok := tt.request.Stream(tt.demand, nil)
+			if !ok {
+				t.Fatalf("request.Stream() failed")
+			}
+			defer streamed.Stream.Close()
+			if _, err := io.ReadAll(streamed.Stream); !errors.Is(err, tt.wantErr) {
+				t.Errorf("read Result.Stream error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
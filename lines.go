@@ -0,0 +1,77 @@
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+//┌ Functions
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// StreamLines send http request and decode streaming response of newline delimited JSON (NDJSON, JSON Lines),
+// one value of T per line, decoded by JsonOptions of request
+// request is sent when iteration starts, retries apply only until response headers are received
+// limit is maximum size of a line in bytes, zero or negative means MAX_LINE_SIZE
+// a line which can not be decoded yields *DecodeError and iteration continues,
+// transport failures, unsuccessful status, ErrLineTooLong and read failures yield an error and stop iteration
+func StreamLines[T any](r Request, c Demand, body io.Reader, limit int) iter.Seq2[T, error] {
+	if limit <= 0 {
+		limit = MAX_LINE_SIZE
+	}
+	// buffer of scanner holds the line and its newline
+	size := limit
+	if limit < math.MaxInt {
+		size = limit + 1
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		result, properties, success := r.Stream(c, body)
+		if !success {
+			yield(zero, errors.Join(c.Error, LastError(properties.Errors)))
+			return
+		}
+		defer result.Stream.Close()
+
+		if result.StatusCode < 200 || result.StatusCode >= 300 {
+			yield(zero, fmt.Errorf("%w: %d", ErrResponseStatus, result.StatusCode))
+			return
+		}
+
+		contentType := mediaType(result.Header.Get("Content-Type"))
+
+		scanner := bufio.NewScanner(result.Stream)
+		scanner.Buffer(make([]byte, 0, min(size, 64*1024)), size)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			var value T
+			if err := result.json.unmarshal(line, &value); err != nil {
+				if !yield(zero, &DecodeError{ContentType: contentType, Err: err}) {
+					return
+				}
+				continue
+			}
+			if !yield(value, nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			if errors.Is(err, bufio.ErrTooLong) {
+				err = fmt.Errorf("%w: exceeds %d bytes", ErrLineTooLong, limit)
+			}
+			yield(zero, err)
+		}
+	}
+}
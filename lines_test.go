@@ -0,0 +1,116 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func TestStreamLines(t *testing.T) {
+	type event struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		switch r.URL.Path {
+		case "/events":
+			w.Write([]byte("{\"id\":1,\"name\":\"a\"}\n\n{\"id\":2,\"name\":\"b\"}\r\n"))
+			w.(http.Flusher).Flush()
+			w.Write([]byte(`{"id":3,"name":"c"}`))
+		case "/broken":
+			w.Write([]byte("{\"id\":1}\n{\"id\":\n{\"id\":3}\n"))
+		case "/long":
+			w.Write([]byte("{\"id\":1}\n{\"name\":\"" + strings.Repeat("x", 64) + "\"}\n{\"id\":3}\n"))
+		case "/boundary":
+			w.Write([]byte("{\"id\":1}\n{\"id\":22}\n"))
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	type item struct {
+		value event
+		err   error
+	}
+	tests := []struct {
+		name  string
+		path  string
+		limit int
+		take  int
+		want  []item
+	}{
+		{
+			name: "lines",
+			path: "/events",
+			want: []item{{value: event{1, "a"}}, {value: event{2, "b"}}, {value: event{3, "c"}}},
+		},
+		{
+			name: "stop early",
+			path: "/events",
+			take: 1,
+			want: []item{{value: event{1, "a"}}},
+		},
+		{
+			name: "broken line continues",
+			path: "/broken",
+			want: []item{{value: event{ID: 1}}, {err: ErrDecodeBody}, {value: event{ID: 3}}},
+		},
+		{
+			name:  "line too long",
+			path:  "/long",
+			limit: 32,
+			want:  []item{{value: event{ID: 1}}, {err: ErrLineTooLong}},
+		},
+		{
+			name:  "line of limit size",
+			path:  "/boundary",
+			limit: 8,
+			want:  []item{{value: event{ID: 1}}, {err: ErrLineTooLong}},
+		},
+		{
+			name: "unsuccessful status",
+			path: "/missing",
+			want: []item{{err: ErrResponseStatus}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []item
+			lines := StreamLines[event](New(MAX_TIMEOUT, []time.Duration{0, 0}), BuildDemand(http.MethodGet, server.URL, tt.path), nil, tt.limit)
+			for value, err := range lines {
+				got = append(got, item{value: value, err: err})
+				if len(got) == tt.take {
+					break
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("StreamLines() yields %d items %v, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if !errors.Is(got[i].err, tt.want[i].err) {
+					t.Errorf("StreamLines() item %d error = %v, want %v", i, got[i].err, tt.want[i].err)
+				}
+				if !reflect.DeepEqual(got[i].value, tt.want[i].value) {
+					t.Errorf("StreamLines() item %d = %v, want %v", i, got[i].value, tt.want[i].value)
+				}
+			}
+		})
+	}
+
+	t.Run("transport failure", func(t *testing.T) {
+		for _, err := range StreamLines[event](New(MAX_TIMEOUT, nil), BuildDemand(http.MethodGet, "http://127.0.0.1:0", ""), nil, 0) {
+			if err == nil {
+				t.Errorf("StreamLines() error = nil, want transport error")
+			}
+		}
+	})
+}
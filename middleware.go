@@ -0,0 +1,66 @@
+package request
+
+import (
+	"io"
+	"slices"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Handler perform a single attempt of request
+type Handler func(c Demand, body io.Reader) (Result, error)
+
+// Middleware wrap a Handler to add behavior around an attempt
+// e.g. signing, logging, header injection, fault injection
+type Middleware func(next Handler) Handler
+
+// defaultMiddlewares built-in middlewares, applied right before sending request
+var defaultMiddlewares = []Middleware{
+	contentTypeMiddleware,
+	authorizationMiddleware,
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Use append middlewares to the chain
+// middlewares run in order of adding, the first one is the outermost
+func (r request) Use(middlewares ...Middleware) Request {
+	r.Middlewares = append(slices.Clip(r.Middlewares), middlewares...)
+	return r
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// handler compose middlewares and default middlewares around do
+func (r request) handler() Handler {
+	var next Handler = r.do
+	for _, middleware := range slices.Backward(defaultMiddlewares) {
+		next = middleware(next)
+	}
+	for _, middleware := range slices.Backward(r.Middlewares) {
+		next = middleware(next)
+	}
+	return next
+}
+
+// contentTypeMiddleware set content type header from Demand.Type
+func contentTypeMiddleware(next Handler) Handler {
+	return func(c Demand, body io.Reader) (Result, error) {
+		if c.Type != "" {
+			c = c.Header("Content-Type", c.Type)
+		}
+		return next(c, body)
+	}
+}
+
+// authorizationMiddleware set authorization header from Demand.Token
+func authorizationMiddleware(next Handler) Handler {
+	return func(c Demand, body io.Reader) (Result, error) {
+		if c.Token != "" {
+			c = c.Header("Authorization", c.Token)
+		}
+		return next(c, body)
+	}
+}
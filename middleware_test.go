@@ -0,0 +1,61 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_Use(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Trace") + "|" + r.Header.Get("Authorization") + "|" + r.Header.Get("Content-Type")))
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(c Demand, body io.Reader) (Result, error) {
+				order = append(order, name)
+				return next(c.Header("X-Trace", c.Headers["X-Trace"]+name), body)
+			}
+		}
+	}
+	errFault := errors.New("fault")
+	faults := 1
+	fault := func(next Handler) Handler {
+		return func(c Demand, body io.Reader) (Result, error) {
+			if faults > 0 {
+				faults--
+				return Result{}, errFault
+			}
+			return next(c, body)
+		}
+	}
+
+	r := New(MAX_TIMEOUT, []time.Duration{0, 0}).Use(trace("a"), trace("b")).Use(fault)
+	c := BuildDemand(http.MethodPost, server.URL, "").Authorization("token")
+
+	got, props, ok := r.SendJson(c, nil)
+	if !ok {
+		t.Fatalf("request.SendJson() errors = %v", props.Errors)
+	}
+	if want := "ab|token|application/json"; string(got.Body) != want {
+		t.Errorf("Result.Body = %q, want %q", got.Body, want)
+	}
+	if props.Retries != 2 || !errors.Is(LastError(props.Errors), errFault) {
+		t.Errorf("Properties = %+v, want 2 retries with fault", props)
+	}
+	if want := []string{"a", "b", "a", "b"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("middlewares order = %v, want %v", order, want)
+	}
+	if _, ok := c.Headers["X-Trace"]; ok {
+		t.Errorf("Demand.Headers modified by middleware")
+	}
+}
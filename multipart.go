@@ -0,0 +1,163 @@
+package request
+
+import (
+	"bytes"
+	"cmp"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Multipart payload of multipart/form-data request
+type Multipart struct {
+	// Fields plain form fields
+	Fields map[string]string
+
+	// Files file parts
+	Files []File
+}
+
+// File a file part of multipart payload
+type File struct {
+	// Field form field name
+	Field string
+
+	// Name file name
+	Name string
+
+	// Type content type of file, "application/octet-stream" if empty
+	Type string
+
+	open func() (io.Reader, error)
+}
+
+//┌ Instance
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// FileFromPath create a file part read from path
+// file name and content type are detected from path, it is reopened on each retry
+func FileFromPath(field string, path string) File {
+	return File{
+		Field: field,
+		Name:  filepath.Base(path),
+		Type:  mime.TypeByExtension(filepath.Ext(path)),
+		open: func() (io.Reader, error) {
+			return os.Open(path)
+		},
+	}
+}
+
+// FileFromBytes create a file part from data
+func FileFromBytes(field string, name string, data []byte) File {
+	return File{
+		Field: field,
+		Name:  name,
+		open: func() (io.Reader, error) {
+			return bytes.NewReader(data), nil
+		},
+	}
+}
+
+// FileFromReader create a file part from reader
+// reader can be sent only once, so retries fail with ErrBodyNotReplayable
+func FileFromReader(field string, name string, reader io.Reader) File {
+	return File{
+		Field: field,
+		Name:  name,
+		open:  singleUse(reader),
+	}
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// SendMultipart send http request with multipart/form-data payload
+// body is streamed, files are opened on each attempt
+func (r request) SendMultipart(c Demand, data Multipart) (Result, Properties, bool) {
+	writer := multipart.NewWriter(io.Discard)
+	boundary := writer.Boundary()
+
+	return r.performPayload(
+		c.ContentType(ContentType(writer.FormDataContentType())),
+		func() (io.Reader, error) {
+			return data.open(boundary)
+		},
+	)
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// open open all files and stream encoded payload through a pipe
+func (m Multipart) open(boundary string) (io.Reader, error) {
+	readers := make([]io.Reader, 0, len(m.Files))
+	for _, file := range m.Files {
+		if file.open == nil {
+			closeAll(readers)
+			return nil, ErrMultipartFileEmpty
+		}
+		reader, err := file.open()
+		if err != nil {
+			closeAll(readers)
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		defer closeAll(readers)
+		pipeWriter.CloseWithError(m.write(pipeWriter, boundary, readers))
+	}()
+
+	return pipeReader, nil
+}
+
+// write encode fields and files into w
+func (m Multipart) write(w io.Writer, boundary string, readers []io.Reader) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(m.Fields)) {
+		if err := writer.WriteField(name, m.Fields[name]); err != nil {
+			return err
+		}
+	}
+
+	for i, file := range m.Files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+			"name":     file.Field,
+			"filename": file.Name,
+		}))
+		header.Set("Content-Type", cmp.Or(file.Type, "application/octet-stream"))
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, readers[i]); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// closeAll close readers which are io.Closer
+func closeAll(readers []io.Reader) {
+	for _, reader := range readers {
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+}
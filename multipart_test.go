@@ -0,0 +1,146 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_SendMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, "title=%s", r.FormValue("title"))
+		for _, headers := range r.MultipartForm.File {
+			for _, header := range headers {
+				file, _ := header.Open()
+				content, _ := io.ReadAll(file)
+				fmt.Fprintf(w, ";%s(%s)=%s", header.Filename, header.Header.Get("Content-Type"), content)
+			}
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "report.txt")
+	if err := os.WriteFile(path, []byte("from path"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// consume body of first attempt and fail, so the second attempt has to reopen payload
+	failures := 1
+	fault := func(next Handler) Handler {
+		return func(c Demand, body io.Reader) (Result, error) {
+			if failures > 0 {
+				failures--
+				io.Copy(io.Discard, body)
+				return Result{}, errors.New("fault")
+			}
+			return next(c, body)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    Multipart
+		want    string
+		wantErr error
+	}{
+		{
+			name: "path",
+			data: Multipart{
+				Fields: map[string]string{"title": "monthly"},
+				Files:  []File{FileFromPath("file", path)},
+			},
+			want: "title=monthly;report.txt(text/plain; charset=utf-8)=from path",
+		},
+		{
+			name: "bytes",
+			data: Multipart{
+				Files: []File{FileFromBytes("file", "data.bin", []byte("from bytes"))},
+			},
+			want: "title=;data.bin(application/octet-stream)=from bytes",
+		},
+		{
+			name: "reader",
+			data: Multipart{
+				Files: []File{FileFromReader("file", "data.bin", strings.NewReader("from reader"))},
+			},
+			wantErr: ErrBodyNotReplayable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures = 1
+			r := New(MAX_TIMEOUT, []time.Duration{0, 0}).Use(fault)
+			got, props, ok := r.SendMultipart(BuildDemand(http.MethodPost, server.URL, ""), tt.data)
+			if tt.wantErr != nil {
+				if ok || !errors.Is(LastError(props.Errors), tt.wantErr) {
+					t.Fatalf("request.SendMultipart() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("request.SendMultipart() errors = %v", props.Errors)
+			}
+			if string(got.Body) != tt.want {
+				t.Errorf("Result.Body = %q, want %q", got.Body, tt.want)
+			}
+		})
+	}
+}
+
+func Test_request_SendMultipart_unread(t *testing.T) {
+	var closed atomic.Int32
+	file := File{
+		Field: "file",
+		Name:  "data.bin",
+		open: func() (io.Reader, error) {
+			return &closeRecorder{Reader: strings.NewReader("content"), closed: &closed}, nil
+		},
+	}
+
+	// fail without reading body, so writer of pipe is left blocked unless body is released
+	errFault := errors.New("fault")
+	fault := func(next Handler) Handler {
+		return func(c Demand, body io.Reader) (Result, error) {
+			return Result{}, errFault
+		}
+	}
+
+	r := New(MAX_TIMEOUT, []time.Duration{0, 0}).Use(fault)
+	_, props, ok := r.SendMultipart(BuildDemand(http.MethodPost, "http://localhost", ""), Multipart{Files: []File{file}})
+	if ok || !errors.Is(LastError(props.Errors), errFault) {
+		t.Fatalf("request.SendMultipart() errors = %v, want %v", props.Errors, errFault)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for closed.Load() != int32(props.Retries) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := closed.Load(); got != int32(props.Retries) {
+		t.Errorf("files closed = %d, want %d", got, props.Retries)
+	}
+}
+
+// closeRecorder reader which counts closing
+type closeRecorder struct {
+	io.Reader
+	closed *atomic.Int32
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed.Add(1)
+	return nil
+}
@@ -0,0 +1,54 @@
+package request
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// payload open a fresh request body for an attempt
+type payload func() (io.Reader, error)
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// replayable make payload from body
+// in-memory bodies (bytes.Buffer, bytes.Reader, strings.Reader) are replayed on every attempt,
+// other readers can be sent only once
+func replayable(body io.Reader) payload {
+	switch reader := body.(type) {
+	case nil:
+		return nil
+	case *bytes.Buffer:
+		return replayBytes(reader.Bytes())
+	case *bytes.Reader, *strings.Reader:
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return func() (io.Reader, error) { return nil, err }
+		}
+		return replayBytes(data)
+	default:
+		return singleUse(reader)
+	}
+}
+
+// replayBytes make payload which opens data on every attempt
+func replayBytes(data []byte) payload {
+	return func() (io.Reader, error) {
+		return bytes.NewReader(data), nil
+	}
+}
+
+// singleUse make payload which opens reader only once, next attempts fail with ErrBodyNotReplayable
+func singleUse(reader io.Reader) payload {
+	used := false
+	return func() (io.Reader, error) {
+		if used {
+			return nil, ErrBodyNotReplayable
+		}
+		used = true
+		return reader, nil
+	}
+}
@@ -0,0 +1,132 @@
+package request
+
+import (
+	"net/http"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// RedirectPolicy controls how redirect responses are followed
+type RedirectPolicy struct {
+	// NoFollow do not follow redirects, the redirect response itself is returned
+	NoFollow bool
+
+	// MaxHops maximum number of redirects to follow, zero means MAX_REDIRECTS
+	MaxHops int
+
+	// SameHost refuse redirects to a host other than the demand host
+	SameHost bool
+
+	// PreserveMethod keep method and body on 301 and 302 redirects, same as 307 and 308
+	// streamed bodies can not be sent again, so the redirect response itself is returned, same as net/http on 307 and 308
+	PreserveMethod bool
+
+	// StripAuthorization remove authorization header on redirects to another host
+	StripAuthorization bool
+}
+
+// RedirectHop a redirect response which has been followed
+type RedirectHop struct {
+	// URL of the request which got redirect response
+	URL string
+
+	// StatusCode http status code of redirect response, e.g. http.StatusFound
+	StatusCode int
+
+	// Location target of redirect
+	Location string
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Redirect set redirect policy
+func (r request) Redirect(policy RedirectPolicy) Request {
+	r.RedirectPolicy = policy
+	return r
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// check build http.Client.CheckRedirect function
+// chain collects all redirect hops
+func (p RedirectPolicy) check(chain *[]RedirectHop) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		initial := via[0]
+		previous := via[len(via)-1]
+
+		hop := RedirectHop{
+			URL:      previous.URL.String(),
+			Location: req.URL.String(),
+		}
+		if req.Response != nil {
+			hop.StatusCode = req.Response.StatusCode
+		}
+		*chain = append(*chain, hop)
+
+		if p.NoFollow {
+			return http.ErrUseLastResponse
+		}
+
+		hops := p.MaxHops
+		if hops <= 0 {
+			hops = MAX_REDIRECTS
+		}
+		if len(via) > hops {
+			return ErrRedirectMaxHops
+		}
+
+		crossHost := req.URL.Host != initial.URL.Host
+		if crossHost && p.SameHost {
+			return ErrRedirectCrossHost
+		}
+		if crossHost && p.StripAuthorization {
+			req.Header.Del("Authorization")
+		}
+
+		if p.PreserveMethod && !seeOther(*chain) {
+			return preserveMethod(req, initial)
+		}
+
+		return nil
+	}
+}
+
+// seeOther report whether chain contains a 303 redirect, which always switches to GET
+func seeOther(chain []RedirectHop) bool {
+	for _, hop := range chain {
+		if hop.StatusCode == http.StatusSeeOther {
+			return true
+		}
+	}
+	return false
+}
+
+// preserveMethod restore method, body and body headers of initial request on redirect request
+// return http.ErrUseLastResponse if body of initial request can not be sent again
+func preserveMethod(req *http.Request, initial *http.Request) error {
+	if initial.Body != nil && initial.Body != http.NoBody && initial.GetBody == nil {
+		return http.ErrUseLastResponse
+	}
+
+	req.Method = initial.Method
+
+	if initial.GetBody != nil && req.Body == nil {
+		body, err := initial.GetBody()
+		if err != nil {
+			return err
+		}
+		req.Body = body
+		req.GetBody = initial.GetBody
+		req.ContentLength = initial.ContentLength
+	}
+
+	for _, name := range []string{"Content-Type", "Content-Encoding", "Content-Language"} {
+		if values, ok := initial.Header[name]; ok {
+			req.Header[name] = values
+		}
+	}
+
+	return nil
+}
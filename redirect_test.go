@@ -0,0 +1,162 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func TestRedirectPolicy_check(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/middle", http.StatusFound)
+	})
+	mux.HandleFunc("/middle", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/end", http.StatusPermanentRedirect)
+	})
+	mux.HandleFunc("/end", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte(r.Method + ":" + string(body)))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		policy     RedirectPolicy
+		method     string
+		streamed   bool
+		wantBody   string
+		wantStatus int
+		wantHops   int
+		wantErr    error
+	}{
+		{
+			name:       "default",
+			policy:     RedirectPolicy{},
+			method:     http.MethodPost,
+			wantBody:   "GET:",
+			wantStatus: http.StatusOK,
+			wantHops:   2,
+		},
+		{
+			name:       "no follow",
+			policy:     RedirectPolicy{NoFollow: true},
+			method:     http.MethodGet,
+			wantStatus: http.StatusFound,
+			wantHops:   1,
+		},
+		{
+			name:     "max hops",
+			policy:   RedirectPolicy{MaxHops: 1},
+			method:   http.MethodGet,
+			wantHops: 2,
+			wantErr:  ErrRedirectMaxHops,
+		},
+		{
+			name:       "preserve method",
+			policy:     RedirectPolicy{PreserveMethod: true},
+			method:     http.MethodPost,
+			wantBody:   "POST:payload",
+			wantStatus: http.StatusOK,
+			wantHops:   2,
+		},
+		{
+			name:       "preserve method of streamed body",
+			policy:     RedirectPolicy{PreserveMethod: true},
+			method:     http.MethodPost,
+			streamed:   true,
+			wantStatus: http.StatusFound,
+			wantHops:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := request{
+				Timeout:        MAX_TIMEOUT,
+				RedirectPolicy: tt.policy,
+			}
+			c := BuildDemand(tt.method, server.URL, "/start")
+			send := func() (Result, Properties, bool) { return r.SendForm(c, "payload") }
+			if tt.streamed {
+				send = func() (Result, Properties, bool) {
+					return r.SendUpload(c.ContentType(HTTP_FORM), Upload{Reader: strings.NewReader("payload")})
+				}
+			}
+			got, props, ok := send()
+			if tt.wantErr != nil {
+				if ok || !errors.Is(LastError(props.Errors), tt.wantErr) {
+					t.Fatalf("request.SendForm() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+				}
+			} else if !ok {
+				t.Fatalf("request.SendForm() errors = %v", props.Errors)
+			}
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("Result.StatusCode = %v, want %v", got.StatusCode, tt.wantStatus)
+			}
+			if tt.wantBody != "" && string(got.Body) != tt.wantBody {
+				t.Errorf("Result.Body = %q, want %q", got.Body, tt.wantBody)
+			}
+			if len(got.Redirects) != tt.wantHops {
+				t.Errorf("len(Result.Redirects) = %v, want %v", len(got.Redirects), tt.wantHops)
+			}
+		})
+	}
+}
+
+func TestRedirectPolicy_crossHost(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer target.Close()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusFound)
+	}))
+	defer origin.Close()
+
+	tests := []struct {
+		name     string
+		policy   RedirectPolicy
+		wantBody string
+		wantErr  error
+	}{
+		{
+			name:    "same host",
+			policy:  RedirectPolicy{SameHost: true},
+			wantErr: ErrRedirectCrossHost,
+		},
+		{
+			name:     "strip authorization",
+			policy:   RedirectPolicy{StripAuthorization: true},
+			wantBody: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := request{
+				Timeout:        MAX_TIMEOUT,
+				RedirectPolicy: tt.policy,
+			}
+			c := BuildDemand(http.MethodGet, origin.URL, "").Authorization("secret")
+			got, props, ok := r.Send(c)
+			if tt.wantErr != nil {
+				if ok || !errors.Is(LastError(props.Errors), tt.wantErr) {
+					t.Fatalf("request.Send() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("request.Send() errors = %v", props.Errors)
+			}
+			if string(got.Body) != tt.wantBody {
+				t.Errorf("Result.Body = %q, want %q", got.Body, tt.wantBody)
+			}
+		})
+	}
+}
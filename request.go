@@ -2,10 +2,14 @@ package request
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
 	"io"
+	"iter"
 	"net/http"
-	net_url "net/url"
+	"net/http/httptrace"
 	"slices"
 	"strings"
 	"time"
@@ -16,12 +20,34 @@ import (
 type Request interface {
 	SendJson(c Demand, data any) (Result, Properties, bool)
 	SendForm(c Demand, data any) (Result, Properties, bool)
+	SendXml(c Demand, data any) (Result, Properties, bool)
+	SendSoap(c Demand, s Soap) (Result, Properties, bool)
+	SendData(c Demand, data any) (Result, Properties, bool)
+	SendRaw(c Demand, body any) (Result, Properties, bool)
+	SendMultipart(c Demand, data Multipart) (Result, Properties, bool)
+	SendUpload(c Demand, u Upload) (Result, Properties, bool)
+	Stream(c Demand, body io.Reader) (Result, Properties, bool)
+	Events(c Demand) iter.Seq2[Event, error]
+	Subscribe(c Demand, events chan<- Event) error
+	MaxResponseSize(size int64, keepTruncated bool) Request
+	Download(c Demand, t Target) (Result, Properties, bool)
+	Compress(compression Compression) Request
+	JsonOptions(options JsonOptions) Request
 	Send(c Demand) (Result, Properties, bool)
+	Redirect(policy RedirectPolicy) Request
+	CookieJar(jar http.CookieJar) Request
+	Use(middlewares ...Middleware) Request
 }
 
 type request struct {
-	Timeout time.Duration
-	Retries []time.Duration
+	Timeout        time.Duration
+	Retries        []time.Duration
+	RedirectPolicy RedirectPolicy
+	Jar            http.CookieJar
+	Middlewares    []Middleware
+	Limit          ResponseLimit
+	Compression    Compression
+	Json           JsonOptions
 }
 
 //┌ Instance
@@ -47,49 +73,112 @@ func New(timeout time.Duration, retries []time.Duration) Request {
 //┌ Methods
 //└─────────────────────────────────────────────────────────────────────────────────────────────────
 
-// SendJson send http request with JSON payload
+// SendJson send http request with JSON payload, encoded by JsonOptions
 // if data is nil then request will be sent without body
-// if can't encode json then empty body will be sent
+// if can't encode json then request is not sent, refer to ErrEncodeBody
 func (r request) SendJson(c Demand, data any) (Result, Properties, bool) {
-	dataByte, err := json.Marshal(data)
+	if c.Error != nil {
+		return Result{}, Properties{}, false
+	}
+	dataByte, err := r.json(c).marshal(data)
 	if err != nil {
-		dataByte = []byte{}
+		return encodeFailure(err)
 	}
-	return r.perform(
+	return r.sendBytes(
 		c.ContentType(HTTP_JSON),
-		bytes.NewBuffer(dataByte),
+		dataByte,
 	)
 }
 
 // SendForm send http request with www form payload
+// data is struct, map or pointer to them encoded by `form` tags, or `string` sent as is, refer to ValuesEncoder
 func (r request) SendForm(c Demand, data any) (Result, Properties, bool) {
-	var body io.Reader
+	if c.Error != nil {
+		return Result{}, Properties{}, false
+	}
+	encode, err := encoder(string(HTTP_FORM))
+	if err != nil {
+		return Result{}, Properties{Errors: []error{err}}, false
+	}
+	body, err := encode(data)
+	if err != nil {
+		return encodeFailure(err)
+	}
+	return r.sendBytes(
+		c.ContentType(HTTP_FORM),
+		body,
+	)
+}
 
-	switch payload := data.(type) {
-	case map[string]string:
-		formData := net_url.Values{}
-		for k, v := range payload {
-			formData[k] = []string{v}
-		}
-		encodedData := formData.Encode()
-		body = strings.NewReader(encodedData)
-	case net_url.Values:
-		encodedData := payload.Encode()
-		body = strings.NewReader(encodedData)
+// SendXml send http request with XML payload
+// if can't encode xml then request is not sent, refer to ErrEncodeBody
+func (r request) SendXml(c Demand, data any) (Result, Properties, bool) {
+	if c.Error != nil {
+		return Result{}, Properties{}, false
+	}
+	encode, err := encoder(string(HTTP_XML))
+	if err != nil {
+		return Result{}, Properties{Errors: []error{err}}, false
+	}
+	body, err := encode(data)
+	if err != nil {
+		return encodeFailure(err)
+	}
+	return r.sendBytes(
+		c.ContentType(HTTP_XML),
+		append([]byte(xml.Header), body...),
+	)
+}
+
+// SendData send http request with payload encoded by codec of Demand.Type, refer to RegisterCodec
+func (r request) SendData(c Demand, data any) (Result, Properties, bool) {
+	if c.Error != nil {
+		return Result{}, Properties{}, false
+	}
+	if c.Type == "" {
+		return Result{}, Properties{Errors: []error{ErrDemandContentTypeEmpty}}, false
+	}
+	encode, err := encoder(c.Type)
+	if err != nil {
+		return Result{}, Properties{Errors: []error{err}}, false
+	}
+	body, err := encode(data)
+	if err != nil {
+		return encodeFailure(err)
+	}
+	return r.sendBytes(c, body)
+}
+
+// SendRaw send http request with pre-encoded body, content type is taken from Demand.Type
+// body is one of `[]byte`, `string`, `io.Reader`, nil means without body
+// Content-Length is set when size of body is known (in-memory bodies, regular files and readers with Len method),
+// otherwise body is sent chunked; readers other than in-memory ones can be sent only once
+// Compression and AttachDigest apply only to in-memory bodies, other readers are streamed as is, same as SendUpload
+func (r request) SendRaw(c Demand, body any) (Result, Properties, bool) {
+	if c.Error != nil {
+		return Result{}, Properties{}, false
+	}
+
+	switch payload := body.(type) {
+	case nil:
+		return r.perform(c, nil)
+	case []byte:
+		return r.sendBytes(c, payload)
 	case string:
-		body = strings.NewReader(payload)
-	default:
-		dataByte, err := json.Marshal(payload)
+		return r.sendBytes(c, []byte(payload))
+	case *bytes.Buffer:
+		return r.sendBytes(c, payload.Bytes())
+	case *bytes.Reader, *strings.Reader:
+		data, err := io.ReadAll(payload.(io.Reader))
 		if err != nil {
-			dataByte = []byte{}
+			return Result{}, Properties{Errors: []error{err}}, false
 		}
-		body = bytes.NewBuffer(dataByte)
+		return r.sendBytes(c, data)
+	case io.Reader:
+		return r.SendUpload(c, Upload{Reader: payload, Length: size(payload)})
+	default:
+		return Result{}, Properties{Errors: []error{fmt.Errorf("%w: %T", ErrRawBodyUnsupported, body)}}, false
 	}
-
-	return r.perform(
-		c.ContentType(HTTP_FORM),
-		body,
-	)
 }
 
 // Send http request
@@ -101,12 +190,40 @@ func (r request) Send(c Demand) (Result, Properties, bool) {
 //┌ Internal Methods
 //└─────────────────────────────────────────────────────────────────────────────────────────────────
 
+// encodeFailure result of request which is not sent because payload can not be encoded
+func encodeFailure(err error) (Result, Properties, bool) {
+	return Result{}, Properties{Errors: []error{fmt.Errorf("%w: %w", ErrEncodeBody, err)}}, false
+}
+
+// sendBytes perform http request with encoded body
+// body is compressed and Content-Digest header is attached if demanded
+func (r request) sendBytes(c Demand, body []byte) (Result, Properties, bool) {
+	body, encoding, err := r.Compression.compress(body)
+	if err != nil {
+		return Result{}, Properties{Errors: []error{err}}, false
+	}
+	if encoding != "" {
+		c = c.Header("Content-Encoding", string(encoding))
+	}
+	return r.perform(
+		c.Integrity.attach(c, body),
+		bytes.NewReader(body),
+	)
+}
+
 // perform http request
+// body is replayed on retries, refer to replayable
+func (r request) perform(c Demand, body io.Reader) (Result, Properties, bool) {
+	return r.performPayload(c, replayable(body))
+}
+
+// performPayload perform http request
 // it silently discards and return unsuccess if c.Error contains error
+// open is called on each attempt to get request body, nil means without body
 // return Result on success
 // return Response with properties and errors
 // return True on success
-func (r request) perform(c Demand, body io.Reader) (result Result, response Properties, isSuccess bool) {
+func (r request) performPayload(c Demand, open payload) (result Result, response Properties, isSuccess bool) {
 	if c.Error != nil {
 		isSuccess = false
 		return //↩️ ∅
@@ -125,12 +242,25 @@ func (r request) perform(c Demand, body io.Reader) (result Result, response Prop
 		r.Retries = []time.Duration{0}
 	}
 
+	handler := r.handler()
+
 	for retryIndex, duration := range r.Retries {
 		response.Retries = retryIndex + 1
 
+		var body io.Reader
+		if open != nil {
+			if body, err = open(); err != nil {
+				response.Errors = append(response.Errors, err)
+				break
+			}
+		}
+
 		begin := time.Now()
-		result, err = r.do(c, body)
+		c.tracer = newTracer()
+		result, err = handler(c, body)
+		release(body)
 		response.Elapsed = time.Since(begin)
+		response.Timings = append(response.Timings, c.tracer.snapshot())
 
 		if err == nil {
 			isSuccess = true
@@ -139,7 +269,9 @@ func (r request) perform(c Demand, body io.Reader) (result Result, response Prop
 
 		response.Errors = append(response.Errors, err)
 
-		time.Sleep(duration)
+		if terminal(err) || wait(c.requestContext(), duration) != nil {
+			break
+		}
 	}
 
 	// All retries failed
@@ -147,55 +279,192 @@ func (r request) perform(c Demand, body io.Reader) (result Result, response Prop
 	return //↩️ ∅
 }
 
+// release close body of an attempt, which may not be read entirely,
+// e.g. when a middleware returns early or connection fails before body is written
+func release(body io.Reader) {
+	switch closer := body.(type) {
+	case *io.PipeReader:
+		// stop writer of pipe, so it releases its sources
+		closer.CloseWithError(io.ErrClosedPipe)
+	case io.Closer:
+		closer.Close()
+	}
+}
+
+// terminal report whether error of an attempt fails the same on retry, so retries are skipped
+func terminal(err error) bool {
+	return errors.Is(err, ErrResponseTooLarge)
+}
+
+// wait pause for duration, return error of context if it is done before
+func wait(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// do perform a single attempt of http request, innermost Handler of middlewares chain
 func (r request) do(c Demand, body io.Reader) (Result, error) {
-	httpRequest, err := http.NewRequest(c.Method, c.GetUrl(), body)
+	var (
+		ctx     = c.requestContext()
+		cancel  = context.CancelFunc(func() {})
+		timeout = r.Timeout
+	)
+	if c.tracer != nil {
+		ctx = httptrace.WithClientTrace(ctx, c.tracer.clientTrace())
+	}
+	if c.stream {
+		// timeout covers only getting response headers, body is read by the caller
+		ctx, cancel = context.WithCancel(ctx)
+		timer := time.AfterFunc(r.Timeout, cancel)
+		defer timer.Stop()
+		timeout = 0
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, c.Method, c.GetUrl(), body)
 	if err != nil {
+		cancel()
 		return Result{}, err
 	}
-	if c.Type != "" {
-		httpRequest.Header.Set("Content-Type", c.Type)
-	}
-	if c.Token != "" {
-		httpRequest.Header.Add("Authorization", c.Token)
+	if upload, ok := body.(*uploadReader); ok {
+		httpRequest.ContentLength = upload.Size()
 	}
 
 	for k, v := range c.Headers {
 		httpRequest.Header.Add(k, v)
 	}
 
+	for _, cookie := range c.Cookies {
+		httpRequest.AddCookie(cookie)
+	}
+
+	negotiated := false
+	accept := r.Compression.accept()
+	if accept == "" && c.Integrity.Headers {
+		// content coding decoded by http.Transport can not be verified, so it is negotiated explicitly
+		accept = string(ENCODING_GZIP)
+	}
+	if accept != "" && httpRequest.Header.Get("Accept-Encoding") == "" {
+		httpRequest.Header.Set("Accept-Encoding", accept)
+		negotiated = true
+	}
+
+	var redirects []RedirectHop
+
 	client := &http.Client{
-		Timeout: r.Timeout,
+		Timeout:       timeout,
+		CheckRedirect: r.RedirectPolicy.check(&redirects),
+		Jar:           r.Jar,
 	}
 	response, err := client.Do(httpRequest)
 	if err != nil {
-		return Result{}, err
+		cancel()
+		return Result{Redirects: redirects}, err
 	}
-	defer response.Body.Close()
 
-	responseBody, err := io.ReadAll(response.Body)
-	if err != nil {
-		return Result{}, err
+	var result = Result{
+		StatusCode:    response.StatusCode,
+		Header:        response.Header,
+		Trailer:       response.Trailer,
+		ContentLength: response.ContentLength,
+		Protocol:      response.Proto,
+		URL:           response.Request.URL.String(),
+		IsOK:          false,
+		Redirects:     redirects,
+		Cookies:       response.Cookies(),
+		json:          r.json(c),
+	}
+	if c.tracer != nil {
+		result.RemoteAddr = c.tracer.snapshot().RemoteAddr
 	}
 
-	bodyObject := map[string]any{}
-	if c.Type == string(HTTP_JSON) && len(responseBody) != 0 {
-		if json.Unmarshal(responseBody, &bodyObject) != nil {
-			bodyObject = nil
+	if response.StatusCode == http.StatusOK {
+		result.IsOK = true
+	}
+
+	stream := r.responseBody(c, response, negotiated, &result)
+
+	if c.stream {
+		result.Stream = &streamBody{
+			ReadCloser: stream,
+			cancel:     cancel,
 		}
-	} else {
-		bodyObject = nil
+		return result, nil
 	}
 
-	var result = Result{
-		StatusCode: response.StatusCode,
-		Body:       responseBody,
-		BodyObject: bodyObject,
-		IsOK:       false,
+	defer stream.Close()
+
+	responseBody, err := io.ReadAll(stream)
+	if errors.Is(err, ErrResponseTooLarge) {
+		if r.limit(c).KeepTruncated {
+			result.Body = responseBody
+		}
+		return result, err
+	}
+	if errors.Is(err, ErrDigestMismatch) {
+		result.Body = responseBody
+		return result, err
+	}
+	if err != nil {
+		// keep status and headers of response, body is not complete
+		return result, err
 	}
 
-	if response.StatusCode == http.StatusOK {
-		result.IsOK = true
+	result.Body = responseBody
+
+	// parse JSON body, falls back to demand content type if response has no content type
+	responseType := mediaType(response.Header.Get("Content-Type"))
+	if isJson(responseType) || (responseType == "" && c.Type == string(HTTP_JSON)) {
+		result.BodyValue = parseJson(responseBody, result.json)
+		result.BodyObject, _ = result.BodyValue.(map[string]any)
 	}
 
 	return result, nil
 }
+
+// responseBody build reader of response body
+// digest headers are verified on content as received, then content is decompressed if negotiated and not empty,
+// limited to maximum size and verified against checksum
+func (r request) responseBody(c Demand, response *http.Response, negotiated bool, result *Result) io.ReadCloser {
+	var body io.ReadCloser = response.Body
+
+	switch {
+	case response.Uncompressed:
+		// decompressed by http.Transport, digest headers refer to compressed content,
+		// it happens only if digest headers are not verified
+		result.ContentEncoding = string(ENCODING_GZIP)
+	case negotiated:
+		result.ContentEncoding = response.Header.Get("Content-Encoding")
+		body = verifying(c.Integrity.headers(response.StatusCode, response.Header), body)
+		if !bodyless(response) {
+			body = decompress(result.ContentEncoding, body)
+		}
+	default:
+		body = verifying(c.Integrity.headers(response.StatusCode, response.Header), body)
+	}
+
+	body = r.limit(c).wrap(body)
+	body = verifying(c.Integrity.checksum(response.StatusCode), body)
+
+	return body
+}
+
+// parseJson parse JSON value of any kind, numbers are float64 unless JsonOptions.UseNumber
+// custom Unmarshal of options is not used, it is meant for typed decoding
+// return nil if data is empty or not a valid JSON
+func parseJson(data []byte, options JsonOptions) any {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var value any
+	if (JsonOptions{UseNumber: options.UseNumber}).unmarshal(data, &value) != nil {
+		return nil
+	}
+	return value
+}
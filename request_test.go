@@ -1,8 +1,17 @@
 package request
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -218,3 +227,268 @@ func Test_request_send(t *testing.T) {
 		})
 	}
 }
+
+func Test_request_do_result(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/items?page=2", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "X-Checksum")
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Link", `</items?page=3>; rel="next"`)
+		w.Write([]byte("items"))
+		w.Header().Set("X-Checksum", "42")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		path              string
+		wantURL           string
+		wantContentLength int64
+	}{
+		{
+			name:              "direct",
+			path:              "/items",
+			wantURL:           server.URL + "/items",
+			wantContentLength: -1,
+		},
+		{
+			name:              "redirected",
+			path:              "/moved",
+			wantURL:           server.URL + "/items?page=2",
+			wantContentLength: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, props, ok := New(MAX_TIMEOUT, nil).Send(BuildDemand(http.MethodGet, server.URL, tt.path))
+			if !ok {
+				t.Fatalf("request.Send() errors = %v", props.Errors)
+			}
+			if got.Header.Get("ETag") != `"abc"` || got.Header.Get("Link") == "" {
+				t.Errorf("Result.Header = %v, want ETag and Link", got.Header)
+			}
+			if got.Trailer.Get("X-Checksum") != "42" {
+				t.Errorf("Result.Trailer = %v, want X-Checksum", got.Trailer)
+			}
+			if got.Protocol != "HTTP/1.1" {
+				t.Errorf("Result.Protocol = %v, want HTTP/1.1", got.Protocol)
+			}
+			if got.URL != tt.wantURL {
+				t.Errorf("Result.URL = %v, want %v", got.URL, tt.wantURL)
+			}
+			if got.RemoteAddr != server.Listener.Addr().String() {
+				t.Errorf("Result.RemoteAddr = %v, want %v", got.RemoteAddr, server.Listener.Addr())
+			}
+			if got.ContentLength != tt.wantContentLength {
+				t.Errorf("Result.ContentLength = %v, want %v", got.ContentLength, tt.wantContentLength)
+			}
+		})
+	}
+}
+
+func Test_parseJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		options JsonOptions
+		want    any
+	}{
+		{name: "empty", data: "", want: nil},
+		{name: "object", data: `{"id":9007199254740993}`, want: map[string]any{"id": float64(9007199254740993)}},
+		{name: "array", data: `[1,"a"]`, want: []any{float64(1), "a"}},
+		{name: "use number", data: `{"id":9007199254740993}`, options: JsonOptions{UseNumber: true}, want: map[string]any{"id": json.Number("9007199254740993")}},
+		{name: "scalar", data: `true`, want: true},
+		{name: "invalid", data: `{"id":`, want: nil},
+		{name: "trailing data", data: `{} {}`, want: nil},
+		{name: "trailing data use number", data: `{} {}`, options: JsonOptions{UseNumber: true}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseJson([]byte(tt.data), tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJson() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_request_do_bodyValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.URL.Query().Get("type"))
+		w.Write([]byte(r.URL.Query().Get("body")))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		params     map[string]string
+		json       JsonOptions
+		wantObject map[string]any
+		wantValue  any
+	}{
+		{
+			name:       "json object on GET",
+			params:     map[string]string{"type": "application/json", "body": `{"a":1}`},
+			wantObject: map[string]any{"a": float64(1)},
+			wantValue:  map[string]any{"a": float64(1)},
+		},
+		{
+			name:       "json object with use number",
+			params:     map[string]string{"type": "application/json", "body": `{"a":1}`},
+			json:       JsonOptions{UseNumber: true},
+			wantObject: map[string]any{"a": json.Number("1")},
+			wantValue:  map[string]any{"a": json.Number("1")},
+		},
+		{
+			name:      "vendor json array",
+			params:    map[string]string{"type": "application/vnd.api+json", "body": `[1]`},
+			wantValue: []any{float64(1)},
+		},
+		{
+			name:   "plain text",
+			params: map[string]string{"type": "text/plain", "body": `{"a":1}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, props, ok := New(MAX_TIMEOUT, nil).JsonOptions(tt.json).Send(BuildDemand(http.MethodGet, server.URL, "").Parameter(tt.params))
+			if !ok {
+				t.Fatalf("request.Send() errors = %v", props.Errors)
+			}
+			if !reflect.DeepEqual(got.BodyObject, tt.wantObject) {
+				t.Errorf("Result.BodyObject = %v, want %v", got.BodyObject, tt.wantObject)
+			}
+			if !reflect.DeepEqual(got.BodyValue, tt.wantValue) {
+				t.Errorf("Result.BodyValue = %v, want %v", got.BodyValue, tt.wantValue)
+			}
+		})
+	}
+}
+
+func Test_request_SendRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Content-Type", r.Header.Get("Content-Type"))
+		w.Header().Set("X-Content-Length", strconv.FormatInt(r.ContentLength, 10))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "body.bin")
+	if err := os.WriteFile(path, []byte("file content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	tests := []struct {
+		name       string
+		ctype      ContentType
+		body       any
+		retries    []time.Duration
+		want       string
+		wantLength string
+		wantErr    error
+	}{
+		{name: "bytes", ctype: "application/octet-stream", body: []byte{0x01, 0x02}, want: "\x01\x02", wantLength: "2"},
+		{name: "string", ctype: "text/plain", body: "hello", want: "hello", wantLength: "5"},
+		{name: "bytes reader replayed", ctype: "text/plain", body: bytes.NewReader([]byte("hello")), retries: []time.Duration{0, 0}, want: "hello", wantLength: "5"},
+		{name: "file", ctype: "application/vnd.acme.v1+binary", body: file, want: "file content", wantLength: "12"},
+		{name: "unknown length", ctype: "text/plain", body: io.MultiReader(strings.NewReader("a"), strings.NewReader("b")), want: "ab", wantLength: "-1"},
+		{name: "no body", ctype: "text/plain", body: nil, want: "", wantLength: "0"},
+		{name: "unsupported", ctype: "text/plain", body: 42, wantErr: ErrRawBodyUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, props, ok := New(MAX_TIMEOUT, tt.retries).SendRaw(BuildDemand(http.MethodPost, server.URL, "").ContentType(tt.ctype), tt.body)
+			if tt.wantErr != nil {
+				if ok || !errors.Is(LastError(props.Errors), tt.wantErr) {
+					t.Fatalf("request.SendRaw() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("request.SendRaw() errors = %v", props.Errors)
+			}
+			if string(got.Body) != tt.want {
+				t.Errorf("request.SendRaw() body = %q, want %q", got.Body, tt.want)
+			}
+			if ctype := got.Header.Get("X-Content-Type"); ctype != string(tt.ctype) {
+				t.Errorf("Content-Type = %s, want %s", ctype, tt.ctype)
+			}
+			if length := got.Header.Get("X-Content-Length"); length != tt.wantLength {
+				t.Errorf("Content-Length = %s, want %s", length, tt.wantLength)
+			}
+		})
+	}
+}
+
+func Test_request_encodeFailure(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer server.Close()
+
+	r := New(MAX_TIMEOUT, []time.Duration{0, 0})
+	d := BuildDemand(http.MethodPost, server.URL, "")
+
+	tests := []struct {
+		name      string
+		send      func() (Result, Properties, bool)
+		wantCause error
+	}{
+		{
+			name:      "json",
+			send:      func() (Result, Properties, bool) { return r.SendJson(d, make(chan int)) },
+			wantCause: new(json.UnsupportedTypeError),
+		},
+		{
+			name:      "form",
+			send:      func() (Result, Properties, bool) { return r.SendForm(d, 42) },
+			wantCause: ErrValuesUnsupported,
+		},
+		{
+			name: "xml",
+			send: func() (Result, Properties, bool) { return r.SendXml(d, make(chan int)) },
+		},
+		{
+			name:      "data",
+			send:      func() (Result, Properties, bool) { return r.SendData(d.ContentType(HTTP_JSON), func() {}) },
+			wantCause: new(json.UnsupportedTypeError),
+		},
+		{
+			name:      "soap",
+			send:      func() (Result, Properties, bool) { return r.SendSoap(d, Soap{Version: "1.0"}) },
+			wantCause: ErrSoapVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits = 0
+			_, props, ok := tt.send()
+			if ok {
+				t.Fatalf("request sent, want encoding failure")
+			}
+			if len(props.Errors) != 1 || !errors.Is(props.Errors[0], ErrEncodeBody) {
+				t.Fatalf("Properties.Errors = %v, want %v", props.Errors, ErrEncodeBody)
+			}
+			if target, ok := tt.wantCause.(*json.UnsupportedTypeError); ok {
+				if !errors.As(props.Errors[0], &target) {
+					t.Errorf("Properties.Errors = %v, want cause %T", props.Errors, target)
+				}
+			} else if tt.wantCause != nil && !errors.Is(props.Errors[0], tt.wantCause) {
+				t.Errorf("Properties.Errors = %v, want cause %v", props.Errors, tt.wantCause)
+			}
+			if hits != 0 || props.Retries != 0 {
+				t.Errorf("attempts = %d, requests = %d, want none", props.Retries, hits)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// segment a range of content downloaded concurrently
+type segment struct {
+	download *download
+	first    int64
+	position int64
+	last     int64
+	resumed  int64
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// probe check whether server supports ranges by requesting the first byte, it is counted as an attempt
+// on support, size and validator of content are kept
+// return error of probe request, it is not added to response errors
+func (d *download) probe(handler Handler, c Demand, count int, response *Properties) (bool, error) {
+	demand := d.ranged(c, 0, 0)
+	begin := time.Now()
+	demand.tracer = newTracer()
+	result, err := handler(demand, nil)
+	response.Retries++
+	response.Elapsed = time.Since(begin)
+	response.Timings = append(response.Timings, demand.tracer.snapshot())
+	if err != nil {
+		return false, err
+	}
+	result.Stream.Close()
+
+	if result.StatusCode != http.StatusPartialContent {
+		return false, nil
+	}
+	_, total, err := parseContentRange(result.Header.Get("Content-Range"))
+	if err != nil || total < int64(count) {
+		return false, nil
+	}
+
+	d.total = total
+	d.validator = validator(result.Header)
+	return true, nil
+}
+
+// segmented download content in count ranges concurrently and write them into their places in file
+// properties of attempts of all segments are aggregated: Retries counts them, Timings are in order of completion,
+// Elapsed is of the last completed attempt, Transferred and Resumed are sums of all segments
+// return result of the first segment
+func (d *download) segmented(retries []time.Duration, handler Handler, c Demand, count int, response *Properties) (Result, error) {
+	if err := d.file.Truncate(d.total); err != nil {
+		response.Errors = append(response.Errors, err)
+		return Result{}, err
+	}
+
+	var (
+		wait    sync.WaitGroup
+		size    = d.total / int64(count)
+		results = make([]Result, count)
+		errs    = make([]error, count)
+	)
+
+	for i := range count {
+		s := &segment{
+			download: d,
+			first:    int64(i) * size,
+			position: int64(i) * size,
+			last:     int64(i+1)*size - 1,
+			resumed:  int64(i) * size,
+		}
+		if i == count-1 {
+			s.last = d.total - 1
+		}
+
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			results[i], errs[i] = s.fetch(retries, handler, c, response)
+		}()
+	}
+	wait.Wait()
+
+	return results[0], errors.Join(errs...)
+}
+
+// fetch download the segment, resuming from current position on retries
+func (s *segment) fetch(retries []time.Duration, handler Handler, c Demand, response *Properties) (result Result, err error) {
+	for _, duration := range retries {
+		demand := s.download.ranged(c, s.position, s.last)
+
+		begin := time.Now()
+		demand.tracer = newTracer()
+		before := s.position
+		// bytes kept by previous resumes are not counted again
+		resumed := max(before-s.resumed, 0)
+		s.resumed = max(s.resumed, before)
+		result, err = s.attempt(handler, demand)
+
+		s.download.mutex.Lock()
+		response.Retries++
+		response.Elapsed = time.Since(begin)
+		response.Timings = append(response.Timings, demand.tracer.snapshot())
+		response.Transferred += max(s.position-before, 0)
+		response.Resumed += resumed
+		if err != nil {
+			response.Errors = append(response.Errors, err)
+		}
+		s.download.mutex.Unlock()
+
+		if err == nil {
+			return result, nil
+		}
+
+		if terminal(err) || permanent(result.StatusCode) || wait(c.requestContext(), duration) != nil {
+			break
+		}
+	}
+
+	// All retries failed
+	return result, err
+}
+
+// attempt perform an attempt of segment download from current position
+func (s *segment) attempt(handler Handler, c Demand) (Result, error) {
+	result, err := handler(c, nil)
+	if err != nil {
+		return result, err
+	}
+	defer result.Stream.Close()
+	stream := idle(result.Stream, s.download.idle)
+	defer stream.stop()
+	result.Stream = nil
+
+	if result.StatusCode != http.StatusPartialContent {
+		return result, fmt.Errorf("%w: %d", ErrDownloadStatus, result.StatusCode)
+	}
+	first, _, err := parseContentRange(result.Header.Get("Content-Range"))
+	if err != nil {
+		return result, err
+	}
+	if first != s.position {
+		return result, fmt.Errorf("%w: range starts at %d, want %d", ErrDownloadRange, first, s.position)
+	}
+
+	remaining := s.last - s.position + 1
+	_, err = io.Copy(s, io.LimitReader(stream, remaining))
+	if errors.Is(err, ErrDigestMismatch) {
+		// corrupted content, start segment over on next attempt
+		s.download.report(s.first - s.position)
+		s.position = s.first
+		s.resumed = s.first
+		return result, err
+	}
+	if err != nil {
+		return result, err
+	}
+	if s.position != s.last+1 {
+		return result, fmt.Errorf("%w: segment ends at %d, want %d", ErrDownloadIncomplete, s.position-1, s.last)
+	}
+
+	return result, nil
+}
+
+// Write implements io.Writer, write into place of segment in file and report progress
+func (s *segment) Write(p []byte) (int, error) {
+	n, err := s.download.file.WriteAt(p, s.position)
+	s.position += int64(n)
+	s.download.report(int64(n))
+	return n, err
+}
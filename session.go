@@ -0,0 +1,150 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/cookiejar"
+	net_url "net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Jar cookie jar which keeps cookies across requests and can be persisted to a JSON file
+// zero value is an empty jar ready to use
+type Jar struct {
+	mutex   sync.Mutex
+	jar     *cookiejar.Jar
+	entries map[string]jarEntry
+}
+
+// jarEntry a cookie with URL it was received from
+type jarEntry struct {
+	URL    string       `json:"url"`
+	Cookie *http.Cookie `json:"cookie"`
+}
+
+//┌ Instance
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// NewSession create a new Request instance sharing a cookie jar across sends
+// timeout and retries are same as New
+func NewSession(timeout time.Duration, retries []time.Duration) Request {
+	return New(timeout, retries).CookieJar(NewJar())
+}
+
+// NewJar create a new empty cookie jar
+func NewJar() *Jar {
+	return &Jar{}
+}
+
+// LoadJar create a new cookie jar from a JSON file saved by (*Jar).Save
+// an empty jar is returned if file does not exist
+func LoadJar(path string) (*Jar, error) {
+	j := NewJar()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []jarEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		u, err := net_url.Parse(entry.URL)
+		if err != nil || entry.Cookie == nil {
+			continue
+		}
+		j.SetCookies(u, []*http.Cookie{entry.Cookie})
+	}
+
+	return j, nil
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// CookieJar set cookie jar shared across sends
+// jar nil disables cookies handling
+func (r request) CookieJar(jar http.CookieJar) Request {
+	r.Jar = jar
+	return r
+}
+
+// SetCookies implements http.CookieJar
+func (j *Jar) SetCookies(u *net_url.URL, cookies []*http.Cookie) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.init()
+
+	now := time.Now()
+	origin := net_url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}
+
+	for _, cookie := range cookies {
+		stored := *cookie
+		if stored.MaxAge > 0 {
+			stored.Expires = now.Add(time.Duration(stored.MaxAge) * time.Second)
+			stored.MaxAge = 0
+		}
+
+		key := u.Hostname() + "|" + stored.Domain + "|" + stored.Path + "|" + stored.Name
+		if stored.MaxAge < 0 || (!stored.Expires.IsZero() && stored.Expires.Before(now)) {
+			delete(j.entries, key)
+		} else {
+			j.entries[key] = jarEntry{URL: origin.String(), Cookie: &stored}
+		}
+	}
+
+	j.jar.SetCookies(u, cookies)
+}
+
+// Cookies implements http.CookieJar
+func (j *Jar) Cookies(u *net_url.URL) []*http.Cookie {
+	j.mutex.Lock()
+	j.init()
+	j.mutex.Unlock()
+	return j.jar.Cookies(u)
+}
+
+// Save write all unexpired cookies to a JSON file
+func (j *Jar) Save(path string) error {
+	j.mutex.Lock()
+	now := time.Now()
+	entries := make([]jarEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		if !entry.Cookie.Expires.IsZero() && entry.Cookie.Expires.Before(now) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	j.mutex.Unlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// init create underlying jar and entries of zero value, mutex must be held
+func (j *Jar) init() {
+	if j.jar == nil {
+		j.jar, _ = cookiejar.New(nil)
+	}
+	if j.entries == nil {
+		j.entries = make(map[string]jarEntry)
+	}
+}
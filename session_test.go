@@ -0,0 +1,100 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func TestNewSession(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t", Path: "/"})
+	})
+	mux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+		for _, cookie := range r.Cookies() {
+			w.Write([]byte(cookie.Name + "=" + cookie.Value + ";"))
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	jar := NewJar()
+	r := New(MAX_TIMEOUT, nil).CookieJar(jar)
+
+	login, _, ok := r.Send(BuildDemand(http.MethodPost, server.URL, "/login"))
+	if !ok {
+		t.Fatalf("request.Send() login failed")
+	}
+	if len(login.Cookies) != 1 || login.Cookies[0].Value != "s3cr3t" {
+		t.Errorf("Result.Cookies = %v, want session cookie", login.Cookies)
+	}
+
+	tests := []struct {
+		name   string
+		demand Demand
+		want   string
+	}{
+		{
+			name:   "jar",
+			demand: BuildDemand(http.MethodGet, server.URL, "/me"),
+			want:   "session=s3cr3t;",
+		},
+		{
+			name:   "explicit cookie",
+			demand: BuildDemand(http.MethodGet, server.URL, "/me").Cookie("lang", "en"),
+			want:   "lang=en;session=s3cr3t;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, ok := r.Send(tt.demand)
+			if !ok {
+				t.Fatalf("request.Send() failed")
+			}
+			if string(got.Body) != tt.want {
+				t.Errorf("Result.Body = %q, want %q", got.Body, tt.want)
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	if err := jar.Save(path); err != nil {
+		t.Fatalf("Jar.Save() error = %v", err)
+	}
+	loaded, err := LoadJar(path)
+	if err != nil {
+		t.Fatalf("LoadJar() error = %v", err)
+	}
+	got, _, _ := New(MAX_TIMEOUT, nil).CookieJar(loaded).Send(BuildDemand(http.MethodGet, server.URL, "/me"))
+	if string(got.Body) != "session=s3cr3t;" {
+		t.Errorf("loaded jar Result.Body = %q, want %q", got.Body, "session=s3cr3t;")
+	}
+}
+
+func TestJar_zero(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("session"); err == nil {
+			w.Write([]byte(cookie.Value))
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t", Path: "/"})
+	}))
+	defer server.Close()
+
+	var jar Jar
+	r := New(MAX_TIMEOUT, nil).CookieJar(&jar)
+	if _, _, ok := r.Send(BuildDemand(http.MethodGet, server.URL, "")); !ok {
+		t.Fatalf("request.Send() failed")
+	}
+	got, _, _ := r.Send(BuildDemand(http.MethodGet, server.URL, ""))
+	if string(got.Body) != "s3cr3t" {
+		t.Errorf("Result.Body = %q, want %q", got.Body, "s3cr3t")
+	}
+	if err := (&Jar{}).Save(filepath.Join(t.TempDir(), "empty.json")); err != nil {
+		t.Errorf("Jar.Save() of zero jar error = %v", err)
+	}
+}
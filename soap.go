@@ -0,0 +1,266 @@
+package request
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// SoapVersion version of SOAP protocol
+type SoapVersion string
+
+const (
+	SOAP_11 SoapVersion = "1.1"
+	SOAP_12 SoapVersion = "1.2"
+)
+
+// Soap SOAP message
+type Soap struct {
+	// Version SOAP version, empty means SOAP_11
+	Version SoapVersion
+
+	// Action SOAP action, sent as SOAPAction header in SOAP 1.1 and as action parameter of content type in SOAP 1.2
+	Action string
+
+	// Header payload of envelope header, nil means no header
+	Header any
+
+	// Body payload of envelope body, encoded by encoding/xml
+	Body any
+}
+
+// SoapFault fault of SOAP response
+type SoapFault struct {
+	// Version SOAP version of response
+	Version SoapVersion
+
+	// Code fault code, faultcode in SOAP 1.1 and Code/Value in SOAP 1.2
+	Code string
+
+	// Subcode fault subcode, SOAP 1.2 only
+	Subcode string
+
+	// Reason human readable description, faultstring in SOAP 1.1 and Reason/Text in SOAP 1.2
+	Reason string
+
+	// Actor source of fault, faultactor in SOAP 1.1 and Role in SOAP 1.2
+	Actor string
+
+	// Detail raw XML content of fault detail, refer to DecodeDetail
+	Detail []byte
+}
+
+// soapFault fault element of SOAP 1.1 and SOAP 1.2
+type soapFault struct {
+	FaultCode   string   `xml:"faultcode"`
+	FaultString string   `xml:"faultstring"`
+	FaultActor  string   `xml:"faultactor"`
+	FaultDetail innerXml `xml:"detail"`
+	Code        struct {
+		Value   string `xml:"Value"`
+		Subcode struct {
+			Value string `xml:"Value"`
+		} `xml:"Subcode"`
+	} `xml:"Code"`
+	Reason struct {
+		Text []string `xml:"Text"`
+	} `xml:"Reason"`
+	Role   string   `xml:"Role"`
+	Detail innerXml `xml:"Detail"`
+}
+
+// innerXml raw content of an element
+type innerXml struct {
+	Content []byte `xml:",innerxml"`
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// SendSoap send http request with payload wrapped in SOAP envelope
+// fault responses are successful requests, use DecodeSoap to get fault
+func (r request) SendSoap(c Demand, s Soap) (Result, Properties, bool) {
+	if c.Error != nil {
+		return Result{}, Properties{}, false
+	}
+	body, err := s.Envelope()
+	if err != nil {
+		return encodeFailure(err)
+	}
+
+	switch s.Version {
+	case SOAP_12:
+		ctype := "application/soap+xml; charset=utf-8"
+		if s.Action != "" {
+			ctype += "; action=" + strconv.Quote(s.Action)
+		}
+		c = c.ContentType(ContentType(ctype))
+	default:
+		c = c.ContentType("text/xml; charset=utf-8").Header("SOAPAction", strconv.Quote(s.Action))
+	}
+
+	return r.sendBytes(c, body)
+}
+
+// Envelope encode SOAP envelope of message
+func (s Soap) Envelope() ([]byte, error) {
+	namespace := s.Version.namespace()
+	if namespace == "" {
+		return nil, fmt.Errorf("%w: %q", ErrSoapVersion, s.Version)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+	fmt.Fprintf(&buffer, `<soap:Envelope xmlns:soap="%s">`, namespace)
+	if s.Header != nil {
+		buffer.WriteString("<soap:Header>")
+		if err := xml.NewEncoder(&buffer).Encode(s.Header); err != nil {
+			return nil, err
+		}
+		buffer.WriteString("</soap:Header>")
+	}
+	buffer.WriteString("<soap:Body>")
+	if s.Body != nil {
+		if err := xml.NewEncoder(&buffer).Encode(s.Body); err != nil {
+			return nil, err
+		}
+	}
+	buffer.WriteString("</soap:Body></soap:Envelope>")
+	return buffer.Bytes(), nil
+}
+
+// Error implements error
+func (f *SoapFault) Error() string {
+	if f.Subcode != "" {
+		return fmt.Sprintf("%s: %s (%s): %s", ErrSoapFault, f.Code, f.Subcode, f.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", ErrSoapFault, f.Code, f.Reason)
+}
+
+// Is report whether target is ErrSoapFault
+func (f *SoapFault) Is(target error) bool {
+	return target == ErrSoapFault
+}
+
+// DecodeDetail decode first element of fault detail into v
+func (f *SoapFault) DecodeDetail(v any) error {
+	return xml.Unmarshal(f.Detail, v)
+}
+
+//┌ Functions
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// DecodeSoap decode first element of SOAP envelope body into T
+// return *SoapFault if body contains a fault, *DecodeError if response is not a valid envelope
+func DecodeSoap[T any](res Result) (T, error) {
+	var value T
+
+	decoder, version, element, err := soapBody(res.Body)
+	if err != nil {
+		return value, &DecodeError{ContentType: mediaType(res.Header.Get("Content-Type")), Err: err}
+	}
+	if element == nil {
+		// empty body
+		return value, nil
+	}
+
+	// fault is an element of envelope namespace, other elements named Fault are payload
+	if element.Name.Local == "Fault" && element.Name.Space == version.namespace() {
+		var fault soapFault
+		if err := decoder.DecodeElement(&fault, element); err != nil {
+			return value, &DecodeError{ContentType: mediaType(res.Header.Get("Content-Type")), Err: err}
+		}
+		return value, fault.fault(version)
+	}
+
+	if err := decoder.DecodeElement(&value, element); err != nil {
+		return value, &DecodeError{ContentType: mediaType(res.Header.Get("Content-Type")), Err: err}
+	}
+	return value, nil
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// namespace get envelope namespace of version, empty if version is not supported
+func (v SoapVersion) namespace() string {
+	switch v {
+	case SOAP_11, "":
+		return "http://schemas.xmlsoap.org/soap/envelope/"
+	case SOAP_12:
+		return "http://www.w3.org/2003/05/soap-envelope"
+	default:
+		return ""
+	}
+}
+
+// soapBody find first element of envelope body, nil if body is empty
+// decoder is positioned after start of returned element
+func soapBody(data []byte) (*xml.Decoder, SoapVersion, *xml.StartElement, error) {
+	var (
+		decoder = xml.NewDecoder(bytes.NewReader(data))
+		version SoapVersion
+		inBody  bool
+	)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("%w: %w", ErrSoapEnvelope, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case version == "":
+				for _, v := range []SoapVersion{SOAP_11, SOAP_12} {
+					if t.Name.Space == v.namespace() && t.Name.Local == "Envelope" {
+						version = v
+					}
+				}
+				if version == "" {
+					return nil, "", nil, fmt.Errorf("%w: unexpected element %q", ErrSoapEnvelope, t.Name.Local)
+				}
+			case inBody:
+				return decoder, version, &t, nil
+			case t.Name.Space == version.namespace() && t.Name.Local == "Body":
+				inBody = true
+			default:
+				// envelope header
+				if err := decoder.Skip(); err != nil {
+					return nil, "", nil, fmt.Errorf("%w: %w", ErrSoapEnvelope, err)
+				}
+			}
+		case xml.EndElement:
+			if inBody {
+				return decoder, version, nil, nil
+			}
+			return nil, "", nil, fmt.Errorf("%w: no body", ErrSoapEnvelope)
+		}
+	}
+}
+
+// fault convert fault element of version into SoapFault
+func (f soapFault) fault(version SoapVersion) *SoapFault {
+	if version == SOAP_12 {
+		fault := &SoapFault{
+			Version: version,
+			Code:    f.Code.Value,
+			Subcode: f.Code.Subcode.Value,
+			Actor:   f.Role,
+			Detail:  f.Detail.Content,
+		}
+		if len(f.Reason.Text) > 0 {
+			fault.Reason = f.Reason.Text[0]
+		}
+		return fault
+	}
+	return &SoapFault{
+		Version: version,
+		Code:    f.FaultCode,
+		Reason:  f.FaultString,
+		Actor:   f.FaultActor,
+		Detail:  f.FaultDetail.Content,
+	}
+}
@@ -0,0 +1,224 @@
+package request
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_SendSoap(t *testing.T) {
+	type getPrice struct {
+		XMLName xml.Name `xml:"urn:stock GetPrice"`
+		Item    string   `xml:"Item"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Header().Set("X-Soap-Action", r.Header.Get("SOAPAction"))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		soap            Soap
+		wantContentType string
+		wantAction      string
+		wantEnvelope    string
+		wantErr         error
+	}{
+		{
+			name:            "soap 1.1",
+			soap:            Soap{Action: "urn:stock#GetPrice", Body: getPrice{Item: "apple"}},
+			wantContentType: "text/xml; charset=utf-8",
+			wantAction:      `"urn:stock#GetPrice"`,
+			wantEnvelope: xml.Header +
+				`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>` +
+				`<GetPrice xmlns="urn:stock"><Item>apple</Item></GetPrice>` +
+				`</soap:Body></soap:Envelope>`,
+		},
+		{
+			name:            "soap 1.2",
+			soap:            Soap{Version: SOAP_12, Action: "urn:stock#GetPrice", Body: getPrice{Item: "apple"}},
+			wantContentType: `application/soap+xml; charset=utf-8; action="urn:stock#GetPrice"`,
+			wantEnvelope: xml.Header +
+				`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body>` +
+				`<GetPrice xmlns="urn:stock"><Item>apple</Item></GetPrice>` +
+				`</soap:Body></soap:Envelope>`,
+		},
+		{
+			name:    "unsupported version",
+			soap:    Soap{Version: "1.0", Body: getPrice{Item: "apple"}},
+			wantErr: ErrSoapVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, props, ok := New(MAX_TIMEOUT, nil).SendSoap(BuildDemand(http.MethodPost, server.URL, ""), tt.soap)
+			if tt.wantErr != nil {
+				if ok || !errors.Is(LastError(props.Errors), tt.wantErr) {
+					t.Fatalf("request.SendSoap() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("request.SendSoap() errors = %v", props.Errors)
+			}
+			if contentType := got.Header.Get("Content-Type"); contentType != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, tt.wantContentType)
+			}
+			if action := got.Header.Get("X-Soap-Action"); action != tt.wantAction {
+				t.Errorf("SOAPAction = %q, want %q", action, tt.wantAction)
+			}
+			if string(got.Body) != tt.wantEnvelope {
+				t.Errorf("envelope = %s, want %s", got.Body, tt.wantEnvelope)
+			}
+		})
+	}
+}
+
+func TestDecodeSoap(t *testing.T) {
+	type getPriceResponse struct {
+		Price float64 `xml:"Price"`
+	}
+	type stockFault struct {
+		Item string `xml:"Item"`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		want       getPriceResponse
+		wantFault  *SoapFault
+		wantDetail stockFault
+		wantErr    error
+	}{
+		{
+			name: "response",
+			body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+				`<soap:Header><Session>1</Session></soap:Header>` +
+				`<soap:Body><m:GetPriceResponse xmlns:m="urn:stock"><m:Price>1.5</m:Price></m:GetPriceResponse></soap:Body>` +
+				`</soap:Envelope>`,
+			want: getPriceResponse{Price: 1.5},
+		},
+		{
+			name: "element named fault in other namespace",
+			body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+				`<soap:Body><Fault xmlns="urn:app"><Price>2</Price></Fault></soap:Body>` +
+				`</soap:Envelope>`,
+			want: getPriceResponse{Price: 2},
+		},
+		{
+			name: "empty body",
+			body: `<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Body/></env:Envelope>`,
+		},
+		{
+			name: "soap 1.1 fault",
+			body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><soap:Fault>` +
+				`<faultcode>soap:Client</faultcode><faultstring>unknown item</faultstring>` +
+				`<detail><StockFault><Item>pear</Item></StockFault></detail>` +
+				`</soap:Fault></soap:Body></soap:Envelope>`,
+			wantFault: &SoapFault{
+				Version: SOAP_11,
+				Code:    "soap:Client",
+				Reason:  "unknown item",
+				Detail:  []byte(`<StockFault><Item>pear</Item></StockFault>`),
+			},
+			wantDetail: stockFault{Item: "pear"},
+			wantErr:    ErrSoapFault,
+		},
+		{
+			name: "soap 1.2 fault",
+			body: `<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Body><env:Fault>` +
+				`<env:Code><env:Value>env:Sender</env:Value><env:Subcode><env:Value>m:UnknownItem</env:Value></env:Subcode></env:Code>` +
+				`<env:Reason><env:Text xml:lang="en">unknown item</env:Text><env:Text xml:lang="fr">article inconnu</env:Text></env:Reason>` +
+				`<env:Role>urn:stock:service</env:Role>` +
+				`</env:Fault></env:Body></env:Envelope>`,
+			wantFault: &SoapFault{
+				Version: SOAP_12,
+				Code:    "env:Sender",
+				Subcode: "m:UnknownItem",
+				Reason:  "unknown item",
+				Actor:   "urn:stock:service",
+			},
+			wantErr: ErrSoapFault,
+		},
+		{
+			name:    "not an envelope",
+			body:    `<GetPriceResponse><Price>1.5</Price></GetPriceResponse>`,
+			wantErr: ErrSoapEnvelope,
+		},
+		{
+			name:    "broken",
+			body:    `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>`,
+			wantErr: ErrDecodeBody,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Result{Body: []byte(tt.body), Header: http.Header{"Content-Type": {"text/xml"}}}
+			got, err := DecodeSoap[getPriceResponse](res)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeSoap() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeSoap() = %v, want %v", got, tt.want)
+			}
+			if tt.wantFault == nil {
+				return
+			}
+			var fault *SoapFault
+			if !errors.As(err, &fault) {
+				t.Fatalf("DecodeSoap() error = %T, want *SoapFault", err)
+			}
+			if !reflect.DeepEqual(fault, tt.wantFault) {
+				t.Errorf("SoapFault = %+v, want %+v", fault, tt.wantFault)
+			}
+			if fault.Detail != nil {
+				var detail stockFault
+				if err := fault.DecodeDetail(&detail); err != nil || detail != tt.wantDetail {
+					t.Errorf("SoapFault.DecodeDetail() = %v, %v, want %v", detail, err, tt.wantDetail)
+				}
+			}
+		})
+	}
+}
+
+func Test_request_SendXml(t *testing.T) {
+	type item struct {
+		XMLName xml.Name `xml:"item"`
+		ID      int64    `xml:"id,attr"`
+		Name    string   `xml:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/atom+xml")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	want := item{XMLName: xml.Name{Local: "item"}, ID: 7, Name: "book"}
+
+	result, props, ok := New(MAX_TIMEOUT, nil).SendXml(BuildDemand(http.MethodPost, server.URL, ""), want)
+	if !ok {
+		t.Fatalf("request.SendXml() errors = %v", props.Errors)
+	}
+	if wantBody := xml.Header + `<item id="7"><name>book</name></item>`; string(result.Body) != wantBody {
+		t.Errorf("request.SendXml() body = %s, want %s", result.Body, wantBody)
+	}
+	got, err := Decode[item](result)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,253 @@
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Event server-sent event
+type Event struct {
+	// ID last event id, sent as Last-Event-ID header on reconnection
+	ID string
+
+	// Type event type, "message" if server does not set it
+	Type string
+
+	// Data data of event, multiple data lines are joined by newline
+	Data string
+}
+
+// eventParser parser of text/event-stream, state is kept across connections
+type eventParser struct {
+	lastEventID string
+	retry       time.Duration
+	eventType   string
+	data        strings.Builder
+	received    bool
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Events subscribe to server-sent events (text/event-stream) and iterate them
+// connection is opened when iteration starts and reopened when it is closed or fails, sending Last-Event-ID,
+// delay of reconnection is retry interval of server if it is sent, otherwise schedule of Request retries,
+// Request retries limit number of reconnections in a row without receiving any event
+// failures yield an error and iteration continues with reconnection,
+// unsuccessful status or content type other than text/event-stream yield an error and stop iteration,
+// status 204 (No Content) stops iteration,
+// canceling context of demand yields context error and stops iteration, refer to Demand.Context
+func (r request) Events(c Demand) iter.Seq2[Event, error] {
+	schedule := r.Retries
+	if len(schedule) == 0 {
+		schedule = []time.Duration{0}
+	}
+
+	// reconnections are retries, a single attempt per connection
+	r.Retries = nil
+
+	return func(yield func(Event, error) bool) {
+		if c.Error != nil {
+			yield(Event{}, c.Error)
+			return
+		}
+
+		var (
+			ctx      = c.requestContext()
+			parser   = &eventParser{}
+			failures = 0
+		)
+		for {
+			demand := c.Header("Accept", "text/event-stream").Header("Cache-Control", "no-cache")
+			if parser.lastEventID != "" {
+				demand = demand.Header("Last-Event-ID", parser.lastEventID)
+			}
+
+			parser.received = false
+			result, properties, success := r.Stream(demand, nil)
+			var err error
+			if success {
+				var done bool
+				done, err = parser.consume(result, yield)
+				if done {
+					return
+				}
+			} else {
+				err = LastError(properties.Errors)
+			}
+
+			if ctx.Err() != nil {
+				yield(Event{}, ctx.Err())
+				return
+			}
+			if err != nil && !yield(Event{}, err) {
+				return
+			}
+
+			if parser.received {
+				failures = 0
+			}
+			if failures >= len(schedule) {
+				return
+			}
+			delay := cmp.Or(parser.retry, schedule[failures])
+			failures++
+
+			if err := wait(ctx, delay); err != nil {
+				yield(Event{}, err)
+				return
+			}
+		}
+	}
+}
+
+// Subscribe subscribe to server-sent events and send them to events channel, refer to Events
+// it blocks until subscription ends, so it is usually called in a goroutine,
+// return error ending the subscription, e.g. context error when context of demand is canceled
+// events channel is not closed
+func (r request) Subscribe(c Demand, events chan<- Event) error {
+	ctx := c.requestContext()
+
+	var err error
+	for event, eventErr := range r.Events(c) {
+		if eventErr != nil {
+			err = eventErr
+			continue
+		}
+		err = nil
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// consume parse events of response and yield them
+// return true if iteration is stopped, by consumer or by response which must not be reconnected
+func (p *eventParser) consume(result Result, yield func(Event, error) bool) (bool, error) {
+	defer result.Stream.Close()
+
+	switch {
+	case result.StatusCode == http.StatusNoContent:
+		return true, nil
+	case result.StatusCode != http.StatusOK:
+		yield(Event{}, fmt.Errorf("%w: %d", ErrResponseStatus, result.StatusCode))
+		return true, nil
+	case mediaType(result.Header.Get("Content-Type")) != "text/event-stream":
+		yield(Event{}, fmt.Errorf("%w: %s", ErrEventStream, result.Header.Get("Content-Type")))
+		return true, nil
+	}
+
+	// pending event of previous connection is discarded
+	p.eventType = ""
+	p.data.Reset()
+
+	scanner := bufio.NewScanner(result.Stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), MAX_LINE_SIZE)
+	scanner.Split(scanEventLines)
+	for first := true; scanner.Scan(); first = false {
+		line := scanner.Text()
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if event, ok := p.line(line); ok {
+			p.received = true
+			if !yield(event, nil) {
+				return true, nil
+			}
+		}
+	}
+
+	err := scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		err = fmt.Errorf("%w: exceeds %d bytes", ErrLineTooLong, MAX_LINE_SIZE)
+	}
+	return false, err
+}
+
+// line process a line of event stream
+// return true and event if line dispatches an event
+func (p *eventParser) line(line string) (Event, bool) {
+	if line == "" {
+		return p.dispatch()
+	}
+	if strings.HasPrefix(line, ":") {
+		// comment
+		return Event{}, false
+	}
+
+	field, value, found := strings.Cut(line, ":")
+	if found {
+		value = strings.TrimPrefix(value, " ")
+	}
+	switch field {
+	case "event":
+		p.eventType = value
+	case "data":
+		p.data.WriteString(value)
+		p.data.WriteByte('\n')
+	case "id":
+		if !strings.ContainsRune(value, 0) {
+			p.lastEventID = value
+		}
+	case "retry":
+		if milliseconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+			p.retry = time.Duration(milliseconds) * time.Millisecond
+		}
+	}
+	return Event{}, false
+}
+
+// dispatch create event of buffered fields and reset buffers
+// return false if there is no data
+func (p *eventParser) dispatch() (Event, bool) {
+	data := p.data.String()
+	eventType := p.eventType
+	p.data.Reset()
+	p.eventType = ""
+
+	if data == "" {
+		return Event{}, false
+	}
+	return Event{
+		ID:   p.lastEventID,
+		Type: cmp.Or(eventType, "message"),
+		Data: strings.TrimSuffix(data, "\n"),
+	}, true
+}
+
+// scanEventLines split function of bufio.Scanner for lines ending with CRLF, LF or CR
+// incomplete line at end of stream is discarded
+func scanEventLines(data []byte, atEOF bool) (int, []byte, error) {
+	i := bytes.IndexAny(data, "\r\n")
+	switch {
+	case i < 0 && atEOF:
+		return len(data), nil, nil
+	case i < 0:
+		return 0, nil, nil
+	case data[i] == '\n':
+		return i + 1, data[:i], nil
+	case i+1 < len(data) && data[i+1] == '\n':
+		return i + 2, data[:i], nil
+	case i+1 < len(data) || atEOF:
+		return i + 1, data[:i], nil
+	default:
+		// CR at end of buffer, LF may follow
+		return 0, nil, nil
+	}
+}
@@ -0,0 +1,147 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_Events(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connection := connections.Add(1)
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		switch r.URL.Path {
+		case "/feed":
+			switch connection {
+			case 1:
+				w.Write([]byte("\ufeffretry: 10\n: comment\nid: 1\nevent: greet\ndata: hello\ndata:world\n\nevent: ignored\ndata: partial"))
+			case 2:
+				if r.Header.Get("Last-Event-ID") != "1" || r.Header.Get("Accept") != "text/event-stream" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte("id: 2\r\ndata: second\r\n\r\ndata\rdata: third\r\r"))
+			default:
+				w.WriteHeader(http.StatusNoContent)
+			}
+		case "/empty":
+			w.Write([]byte(": nothing\n\n"))
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("{}"))
+		}
+	}))
+	defer server.Close()
+
+	type item struct {
+		event Event
+		err   error
+	}
+	tests := []struct {
+		name            string
+		path            string
+		retries         []time.Duration
+		want            []item
+		wantConnections int32
+	}{
+		{
+			name: "reconnect with last event id",
+			path: "/feed",
+			want: []item{
+				{event: Event{ID: "1", Type: "greet", Data: "hello\nworld"}},
+				{event: Event{ID: "2", Type: "message", Data: "second"}},
+				{event: Event{ID: "2", Type: "message", Data: "\nthird"}},
+			},
+			wantConnections: 3,
+		},
+		{
+			name:            "reconnections without events are limited by retries",
+			path:            "/empty",
+			retries:         []time.Duration{0, time.Millisecond},
+			wantConnections: 3,
+		},
+		{
+			name:            "unsuccessful status",
+			path:            "/missing",
+			want:            []item{{err: ErrResponseStatus}},
+			wantConnections: 1,
+		},
+		{
+			name:            "not an event stream",
+			path:            "/json",
+			want:            []item{{err: ErrEventStream}},
+			wantConnections: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connections.Store(0)
+			var got []item
+			for event, err := range New(MAX_TIMEOUT, tt.retries).Events(BuildDemand(http.MethodGet, server.URL, tt.path)) {
+				got = append(got, item{event: event, err: err})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("request.Events() yields %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !errors.Is(got[i].err, tt.want[i].err) {
+					t.Errorf("request.Events() item %d error = %v, want %v", i, got[i].err, tt.want[i].err)
+				}
+				if !reflect.DeepEqual(got[i].event, tt.want[i].event) {
+					t.Errorf("request.Events() item %d = %+v, want %+v", i, got[i].event, tt.want[i].event)
+				}
+			}
+			if n := connections.Load(); n != tt.wantConnections {
+				t.Errorf("connections = %d, want %d", n, tt.wantConnections)
+			}
+		})
+	}
+}
+
+func Test_request_Subscribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: tick\n\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan Event)
+	done := make(chan error, 1)
+	go func() {
+		done <- New(MAX_TIMEOUT, nil).Subscribe(BuildDemand(http.MethodGet, server.URL, "").Context(ctx), events)
+	}()
+
+	select {
+	case event := <-events:
+		if event.Data != "tick" {
+			t.Errorf("event.Data = %q, want %q", event.Data, "tick")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("request.Subscribe() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request.Subscribe() does not return after cancel")
+	}
+}
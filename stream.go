@@ -0,0 +1,35 @@
+package request
+
+import (
+	"context"
+	"io"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// streamBody response body of streaming request, releases request context on close
+type streamBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Stream send http request and return response body as Result.Stream without reading it
+// retries apply only until response headers are received
+// timeout covers only getting response headers
+// caller is responsible for closing Result.Stream
+func (r request) Stream(c Demand, body io.Reader) (Result, Properties, bool) {
+	c.stream = true
+	return r.perform(c, body)
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Close implements io.Closer
+func (s *streamBody) Close() error {
+	defer s.cancel()
+	return s.ReadCloser.Close()
+}
@@ -0,0 +1,58 @@
+package request
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Part", "first")
+		w.Write([]byte("first;"))
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("second;"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    string
+	}{
+		{
+			name:    "body slower than timeout",
+			timeout: 50 * time.Millisecond,
+			want:    "first;second;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := request{Timeout: tt.timeout}
+			got, props, ok := r.Stream(BuildDemand(http.MethodGet, server.URL, ""), nil)
+			if !ok {
+				t.Fatalf("request.Stream() errors = %v", props.Errors)
+			}
+			defer got.Stream.Close()
+
+			if got.Body != nil {
+				t.Errorf("Result.Body = %q, want nil", got.Body)
+			}
+			if got.Header.Get("X-Part") != "first" {
+				t.Errorf("Result.Header = %v, want X-Part", got.Header)
+			}
+			body, err := io.ReadAll(got.Stream)
+			if err != nil {
+				t.Fatalf("read Result.Stream error = %v", err)
+			}
+			if string(body) != tt.want {
+				t.Errorf("Result.Stream = %q, want %q", body, tt.want)
+			}
+		})
+	}
+}
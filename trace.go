@@ -0,0 +1,113 @@
+package request
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Timing connection phases of an attempt
+type Timing struct {
+	// DNS time spend to resolve host name
+	DNS time.Duration
+
+	// Connect time spend to establish TCP connection
+	Connect time.Duration
+
+	// TLS time spend on TLS handshake
+	TLS time.Duration
+
+	// FirstByte time from request written to first response byte, indeed server think time,
+	// zero if response arrives before request is written entirely
+	FirstByte time.Duration
+
+	// Reused is connection reused from a previous request
+	Reused bool
+
+	// RemoteAddr address of server of connection
+	RemoteAddr string
+}
+
+// tracer collects Timing of an attempt
+type tracer struct {
+	mutex  *sync.Mutex
+	timing Timing
+
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wrote        time.Time
+}
+
+//┌ Instance
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// newTracer create a new tracer instance
+func newTracer() *tracer {
+	return &tracer{
+		mutex: &sync.Mutex{},
+	}
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// snapshot get collected timing
+func (t *tracer) snapshot() Timing {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.timing
+}
+
+// clientTrace build httptrace.ClientTrace which records into tracer
+func (t *tracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.record(func() { t.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.record(func() { t.timing.DNS = time.Since(t.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			t.record(func() { t.connectStart = time.Now() })
+		},
+		ConnectDone: func(string, string, error) {
+			t.record(func() { t.timing.Connect = time.Since(t.connectStart) })
+		},
+		TLSHandshakeStart: func() {
+			t.record(func() { t.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.record(func() { t.timing.TLS = time.Since(t.tlsStart) })
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.record(func() {
+				t.timing.Reused = info.Reused
+				if info.Conn != nil {
+					t.timing.RemoteAddr = info.Conn.RemoteAddr().String()
+				}
+			})
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.record(func() { t.wrote = time.Now() })
+		},
+		GotFirstResponseByte: func() {
+			t.record(func() {
+				// response may arrive before request is written entirely, e.g. early response or error
+				if !t.wrote.IsZero() {
+					t.timing.FirstByte = time.Since(t.wrote)
+				}
+			})
+		},
+	}
+}
+
+// record run fn exclusively, trace hooks may be called from different goroutines
+func (t *tracer) record(fn func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	fn()
+}
@@ -0,0 +1,56 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_tracer_clientTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	r := New(MAX_TIMEOUT, nil)
+	c := BuildDemand(http.MethodGet, server.URL, "")
+
+	tests := []struct {
+		name       string
+		wantReused bool
+	}{
+		{name: "new connection", wantReused: false},
+		{name: "reused connection", wantReused: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, props, ok := r.Send(c)
+			if !ok {
+				t.Fatalf("request.Send() errors = %v", props.Errors)
+			}
+			if len(props.Timings) != 1 {
+				t.Fatalf("len(Properties.Timings) = %v, want 1", len(props.Timings))
+			}
+			timing := props.Timings[0]
+			if timing.Reused != tt.wantReused {
+				t.Errorf("Timing.Reused = %v, want %v", timing.Reused, tt.wantReused)
+			}
+			if timing.FirstByte < 10*time.Millisecond {
+				t.Errorf("Timing.FirstByte = %v, want at least 10ms", timing.FirstByte)
+			}
+		})
+	}
+}
+
+func Test_tracer_clientTrace_notWritten(t *testing.T) {
+	tr := newTracer()
+	trace := tr.clientTrace()
+	trace.GotFirstResponseByte()
+	if got := tr.snapshot().FirstByte; got != 0 {
+		t.Errorf("Timing.FirstByte = %v, want 0 when request is not written", got)
+	}
+}
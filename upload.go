@@ -0,0 +1,116 @@
+package request
+
+import (
+	"io"
+	"io/fs"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Upload streamed request body
+type Upload struct {
+	// Reader source of body, it can be sent only once
+	Reader io.Reader
+
+	// Open factory of body, called on each attempt, takes precedence over Reader
+	Open func() (io.Reader, error)
+
+	// Length size of body, zero or negative means unknown and chunked transfer encoding is used
+	Length int64
+
+	// Progress called while body is sent, total is -1 when Length is unknown
+	Progress func(sent int64, total int64)
+}
+
+// uploadReader counts bytes read from body and reports progress
+type uploadReader struct {
+	reader   io.Reader
+	sent     int64
+	total    int64
+	progress func(sent int64, total int64)
+}
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// SendUpload send http request with streamed body
+// content type is taken from Demand.Type
+// body is sent as is, Compression and AttachDigest do not apply to streamed bodies
+func (r request) SendUpload(c Demand, u Upload) (Result, Properties, bool) {
+	open := u.Open
+	if open == nil && u.Reader != nil {
+		open = singleUse(u.Reader)
+	}
+	if open == nil {
+		return r.performPayload(c, nil)
+	}
+
+	total := u.Length
+	if total <= 0 {
+		total = -1
+	}
+
+	return r.performPayload(c, func() (io.Reader, error) {
+		reader, err := open()
+		if err != nil {
+			return nil, err
+		}
+		return &uploadReader{
+			reader:   reader,
+			total:    total,
+			progress: u.Progress,
+		}, nil
+	})
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Read implements io.Reader
+func (u *uploadReader) Read(p []byte) (int, error) {
+	n, err := u.reader.Read(p)
+	if n > 0 {
+		u.sent += int64(n)
+		if u.progress != nil {
+			u.progress(u.sent, u.total)
+		}
+	}
+	return n, err
+}
+
+// Close implements io.Closer, close underlying reader if it is io.Closer
+func (u *uploadReader) Close() error {
+	if closer, ok := u.reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Size size of body, -1 if unknown
+func (u *uploadReader) Size() int64 {
+	return u.total
+}
+
+// size get remaining size of reader, zero if it is unknown
+// size is known for readers with Len method and regular files
+func size(reader io.Reader) int64 {
+	switch source := reader.(type) {
+	case interface{ Len() int }:
+		return int64(source.Len())
+	case interface {
+		Stat() (fs.FileInfo, error)
+		io.Seeker
+	}:
+		info, err := source.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0
+		}
+		offset, err := source.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0
+		}
+		return max(info.Size()-offset, 0)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,67 @@
+package request
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func Test_request_SendUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%d|%v|%s", r.ContentLength, r.TransferEncoding, body)
+	}))
+	defer server.Close()
+
+	const content = "streamed content"
+
+	tests := []struct {
+		name   string
+		upload Upload
+		want   string
+		total  int64
+	}{
+		{
+			name: "known length",
+			upload: Upload{
+				Open:   func() (io.Reader, error) { return strings.NewReader(content), nil },
+				Length: int64(len(content)),
+			},
+			want:  "16|[]|" + content,
+			total: 16,
+		},
+		{
+			name: "chunked",
+			upload: Upload{
+				Reader: io.MultiReader(strings.NewReader(content)),
+			},
+			want:  "-1|[chunked]|" + content,
+			total: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent, total int64
+			tt.upload.Progress = func(s int64, t int64) {
+				sent, total = s, t
+			}
+
+			c := BuildDemand(http.MethodPut, server.URL, "").ContentType("text/plain")
+			got, props, ok := New(MAX_TIMEOUT, nil).SendUpload(c, tt.upload)
+			if !ok {
+				t.Fatalf("request.SendUpload() errors = %v", props.Errors)
+			}
+			if string(got.Body) != tt.want {
+				t.Errorf("Result.Body = %q, want %q", got.Body, tt.want)
+			}
+			if sent != int64(len(content)) || total != tt.total {
+				t.Errorf("Progress = (%v, %v), want (%v, %v)", sent, total, len(content), tt.total)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package request
+
+import (
+	"cmp"
+	"encoding"
+	"fmt"
+	net_url "net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Nesting notation of keys of nested structs and maps
+type Nesting string
+
+const (
+	NESTING_DOT     Nesting = "dot"     // e.g. "address.city"
+	NESTING_BRACKET Nesting = "bracket" // e.g. "address[city]"
+)
+
+// ArrayStyle style of slices of scalars
+type ArrayStyle string
+
+const (
+	ARRAY_REPEAT  ArrayStyle = "repeat"  // e.g. "tags=a&tags=b"
+	ARRAY_COMMA   ArrayStyle = "comma"   // e.g. "tags=a,b"
+	ARRAY_BRACKET ArrayStyle = "bracket" // e.g. "tags[]=a&tags[]=b"
+)
+
+// ValuesEncoder encoder of structs and maps into url.Values
+//
+// struct fields are named by Tag, e.g. `form:"name,omitempty"`, "-" skips the field,
+// untagged fields use field name and untagged embedded structs are flattened,
+// time.Time is formatted by TimeFormat and encoding.TextMarshaler by MarshalText,
+// slices of scalars are encoded by Arrays style and slices of structs and maps are indexed
+type ValuesEncoder struct {
+	// Tag struct tag of field names
+	Tag string
+
+	// Nesting notation of nested keys, empty means NESTING_DOT
+	Nesting Nesting
+
+	// Arrays style of slices of scalars, empty means ARRAY_REPEAT
+	Arrays ArrayStyle
+
+	// TimeFormat layout of time.Time, empty means time.RFC3339
+	TimeFormat string
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+//┌ Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// Encode encode struct, map with string keys or pointer to them into url.Values
+// nil pointer results empty values
+func (e ValuesEncoder) Encode(v any) (net_url.Values, error) {
+	values := net_url.Values{}
+
+	value, ok := indirect(reflect.ValueOf(v))
+
+	switch {
+	case !ok:
+		return values, nil
+	case value.Kind() == reflect.Struct && value.Type() != timeType,
+		value.Kind() == reflect.Map:
+		if err := e.encode(values, "", value); err != nil {
+			return nil, err
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrValuesUnsupported, value.Type())
+	}
+}
+
+//┌ Functions
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// FormCodec create www form codec encoding by encoder, refer to RegisterCodec
+func FormCodec(encoder ValuesEncoder) Codec {
+	return Codec{
+		Encode: func(data any) ([]byte, error) {
+			return encodeForm(encoder, data)
+		},
+		Decode: decodeForm,
+	}
+}
+
+//┌ Internal Methods
+//└─────────────────────────────────────────────────────────────────────────────────────────────────
+
+// encode add value under key to values
+func (e ValuesEncoder) encode(values net_url.Values, key string, value reflect.Value) error {
+	value, ok := indirect(value)
+	if !ok {
+		return nil
+	}
+
+	if text, ok, err := e.text(value); ok {
+		if err != nil {
+			return err
+		}
+		values.Add(key, text)
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return e.encodeStruct(values, key, value)
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("%w: %s", ErrValuesUnsupported, value.Type())
+		}
+		keys := value.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, k := range keys {
+			if err := e.encode(values, e.join(key, k.String()), value.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		return e.encodeSlice(values, key, value)
+	default:
+		return fmt.Errorf("%w: %s", ErrValuesUnsupported, value.Type())
+	}
+}
+
+// encodeStruct add exported fields of struct under key to values
+func (e ValuesEncoder) encodeStruct(values net_url.Values, key string, value reflect.Value) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup(e.Tag)
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" && options == "" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if slices.Contains(strings.Split(options, ","), "omitempty") && fieldValue.IsZero() {
+			continue
+		}
+
+		if field.Anonymous && !tagged {
+			embedded, _ := indirect(fieldValue)
+			if embedded.Kind() == reflect.Struct && embedded.Type() != timeType {
+				if err := e.encodeStruct(values, key, embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if err := e.encode(values, e.join(key, name), fieldValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeSlice add elements of slice under key to values
+func (e ValuesEncoder) encodeSlice(values net_url.Values, key string, value reflect.Value) error {
+	var texts []string
+	for i := 0; i < value.Len(); i++ {
+		element := value.Index(i)
+		if e.composite(element) {
+			if err := e.encode(values, e.join(key, strconv.Itoa(i)), element); err != nil {
+				return err
+			}
+			continue
+		}
+
+		element, ok := indirect(element)
+		if !ok {
+			continue
+		}
+		text, ok, err := e.text(element)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrValuesUnsupported, element.Type())
+		}
+		texts = append(texts, text)
+	}
+
+	switch e.Arrays {
+	case ARRAY_COMMA:
+		if len(texts) > 0 {
+			values.Add(key, strings.Join(texts, ","))
+		}
+	case ARRAY_BRACKET:
+		for _, text := range texts {
+			values.Add(key+"[]", text)
+		}
+	default:
+		for _, text := range texts {
+			values.Add(key, text)
+		}
+	}
+	return nil
+}
+
+// text format scalar value as text
+// return false if value is not scalar
+func (e ValuesEncoder) text(value reflect.Value) (string, bool, error) {
+	if value.Type() == timeType {
+		return value.Interface().(time.Time).Format(cmp.Or(e.TimeFormat, time.RFC3339)), true, nil
+	}
+	if value.Type().Implements(textMarshalerType) {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), true, err
+	}
+	if value.CanAddr() && reflect.PointerTo(value.Type()).Implements(textMarshalerType) {
+		text, err := value.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), true, err
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), true, nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// composite report whether value is encoded under nested keys
+func (e ValuesEncoder) composite(value reflect.Value) bool {
+	value, ok := indirect(value)
+	if !ok {
+		return false
+	}
+	valueType := value.Type()
+	if valueType == timeType || valueType.Implements(textMarshalerType) || reflect.PointerTo(valueType).Implements(textMarshalerType) {
+		return false
+	}
+	return valueType.Kind() == reflect.Struct || valueType.Kind() == reflect.Map
+}
+
+// indirect dereference pointers and interfaces of value
+// return false if value is nil
+func indirect(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+	return value, value.IsValid()
+}
+
+// join join key of nested name by notation of encoder
+func (e ValuesEncoder) join(key string, name string) string {
+	switch {
+	case key == "":
+		return name
+	case e.Nesting == NESTING_BRACKET:
+		return key + "[" + name + "]"
+	default:
+		return key + "." + name
+	}
+}
@@ -0,0 +1,189 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	net_url "net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+//──────────────────────────────────────────────────────────────────────────────────────────────────
+
+func TestValuesEncoder_Encode(t *testing.T) {
+	type address struct {
+		City string `form:"city"`
+		Zip  string `form:"zip,omitempty"`
+	}
+	type Audit struct {
+		Created time.Time `form:"created"`
+	}
+	type signup struct {
+		Audit
+		Name     string    `form:"name"`
+		Age      int       `form:"age,omitempty"`
+		Nick     string    `form:"nick,string,omitempty"`
+		Admin    bool      `form:"-"`
+		Score    float64   `form:"score"`
+		Tags     []string  `form:"tags"`
+		Address  address   `form:"address"`
+		Previous []address `form:"previous"`
+		IP       net.IP    `form:"ip,omitempty"`
+		Note     *string   `form:"note"`
+		Plain    string
+		secret   string
+	}
+	created := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	value := signup{
+		Audit:    Audit{Created: created},
+		Name:     "Ada",
+		Admin:    true,
+		Score:    9.5,
+		Tags:     []string{"a", "b"},
+		Address:  address{City: "Paris"},
+		Previous: []address{{City: "Rome", Zip: "00100"}},
+		IP:       net.ParseIP("10.0.0.1"),
+		Plain:    "p",
+		secret:   "s",
+	}
+
+	tests := []struct {
+		name    string
+		encoder ValuesEncoder
+		v       any
+		want    net_url.Values
+		wantErr error
+	}{
+		{
+			name:    "struct with dot notation",
+			encoder: ValuesEncoder{Tag: "form"},
+			v:       value,
+			want: net_url.Values{
+				"created":         {"2024-05-01T10:30:00Z"},
+				"name":            {"Ada"},
+				"score":           {"9.5"},
+				"tags":            {"a", "b"},
+				"address.city":    {"Paris"},
+				"previous.0.city": {"Rome"},
+				"previous.0.zip":  {"00100"},
+				"ip":              {"10.0.0.1"},
+				"Plain":           {"p"},
+			},
+		},
+		{
+			name:    "pointer with bracket notation and time format",
+			encoder: ValuesEncoder{Tag: "form", Nesting: NESTING_BRACKET, TimeFormat: time.DateOnly},
+			v:       &value,
+			want: net_url.Values{
+				"created":           {"2024-05-01"},
+				"name":              {"Ada"},
+				"score":             {"9.5"},
+				"tags":              {"a", "b"},
+				"address[city]":     {"Paris"},
+				"previous[0][city]": {"Rome"},
+				"previous[0][zip]":  {"00100"},
+				"ip":                {"10.0.0.1"},
+				"Plain":             {"p"},
+			},
+		},
+		{
+			name:    "map",
+			encoder: ValuesEncoder{Tag: "form", Nesting: NESTING_BRACKET},
+			v:       map[string]any{"q": "go", "page": 2, "filter": map[string]string{"lang": "en"}},
+			want:    net_url.Values{"q": {"go"}, "page": {"2"}, "filter[lang]": {"en"}},
+		},
+		{
+			name:    "values",
+			encoder: ValuesEncoder{Tag: "form"},
+			v:       net_url.Values{"a": {"1", "2"}},
+			want:    net_url.Values{"a": {"1", "2"}},
+		},
+		{
+			name:    "nil pointer",
+			encoder: ValuesEncoder{Tag: "form"},
+			v:       (*signup)(nil),
+			want:    net_url.Values{},
+		},
+		{
+			name:    "unsupported top level",
+			encoder: ValuesEncoder{Tag: "form"},
+			v:       []string{"a"},
+			wantErr: ErrValuesUnsupported,
+		},
+		{
+			name:    "unsupported field",
+			encoder: ValuesEncoder{Tag: "form"},
+			v:       struct{ Done chan bool }{Done: make(chan bool)},
+			wantErr: ErrValuesUnsupported,
+		},
+		{
+			name:    "unsupported map key",
+			encoder: ValuesEncoder{Tag: "form"},
+			v:       map[int]string{1: "a"},
+			wantErr: ErrValuesUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.encoder.Encode(tt.v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValuesEncoder.Encode() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValuesEncoder.Encode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_request_SendForm_struct(t *testing.T) {
+	type login struct {
+		User     string `form:"user"`
+		Password string `form:"password"`
+		Remember bool   `form:"remember,omitempty"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Content-Type", r.Header.Get("Content-Type"))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		data    any
+		want    string
+		wantErr error
+	}{
+		{name: "struct", data: login{User: "ada", Password: "p&q"}, want: "password=p%26q&user=ada"},
+		{name: "pointer", data: &login{User: "ada", Remember: true}, want: "password=&remember=true&user=ada"},
+		{name: "map", data: map[string]string{"user": "ada"}, want: "user=ada"},
+		{name: "string", data: "user=ada", want: "user=ada"},
+		{name: "unsupported", data: 42, wantErr: ErrValuesUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, props, ok := New(MAX_TIMEOUT, nil).SendForm(BuildDemand(http.MethodPost, server.URL, ""), tt.data)
+			if tt.wantErr != nil {
+				if ok || !errors.Is(LastError(props.Errors), tt.wantErr) {
+					t.Fatalf("request.SendForm() error = %v, want %v", LastError(props.Errors), tt.wantErr)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("request.SendForm() errors = %v", props.Errors)
+			}
+			if string(got.Body) != tt.want {
+				t.Errorf("request.SendForm() body = %s, want %s", got.Body, tt.want)
+			}
+			if ctype := got.Header.Get("X-Content-Type"); ctype != string(HTTP_FORM) {
+				t.Errorf("Content-Type = %s, want %s", ctype, HTTP_FORM)
+			}
+		})
+	}
+}